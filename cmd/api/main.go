@@ -3,40 +3,83 @@ package main
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/RiosHectorM/iso-stack/internal/adapters/auth"
 	"github.com/RiosHectorM/iso-stack/internal/adapters/handlers"
+	"github.com/RiosHectorM/iso-stack/internal/adapters/notify"
+	"github.com/RiosHectorM/iso-stack/internal/adapters/report"
 	"github.com/RiosHectorM/iso-stack/internal/adapters/repository"
+	"github.com/RiosHectorM/iso-stack/internal/adapters/storage"
 	"github.com/RiosHectorM/iso-stack/internal/config"
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
 	"github.com/RiosHectorM/iso-stack/internal/core/services"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 )
 
 func main() {
 	cfg := config.LoadConfig()
 
 	// 1. Adapters (Repository & Auth)
-	// NewPostgresDB returns *PostgresRepository which implements ports.AuthRepository, OrgRepo, AuditRepo
-	repo := repository.NewPostgresDB(cfg.DBDSN)
-	jwtAdapter := &auth.JWTAdapter{Secret: cfg.JWTSecret}
+	// El repositorio (Postgres o SQLite) implementa todos los puertos de persistencia
+	repo := newRepository(cfg)
+	jwtAdapter := &auth.JWTAdapter{Secret: cfg.JWTSecret, AccessTTL: cfg.AccessTokenTTL}
+	blobStore := newBlobStore(cfg)
+	mailer := services.NewMailer(newNotifier(cfg), cfg.NotifyLocale, cfg.AppBaseURL)
+	activityLog := services.NewActivityLog(repo)
 
 	// 2. Application Core (Services)
-	authService := services.NewAuthService(repo, jwtAdapter)
-	orgService := services.NewOrganizationService(repo, repo) // Repo implements both interfaces
-	auditService := services.NewAuditService(repo, repo)
+	authService := services.NewAuthService(repo, jwtAdapter, cfg.RefreshTokenTTL, activityLog)
+	orgService := services.NewOrganizationService(repo, repo, repo, mailer, cfg.JWTSecret, cfg.InvitationTTL, activityLog) // Repo implements all three interfaces
+	auditService := services.NewAuditService(repo, repo, repo, repo, repo, mailer, cfg.JWTSecret, cfg.TempLinkTTL, activityLog)
+	planningService := services.NewPlanningService(repo, repo)
+	calendarService := services.NewCalendarService(repo, repo, repo, cfg.JWTSecret)
+	clientService := services.NewClientService(repo, repo, orgService)
+	auditeeService := services.NewAuditeeService(repo, repo, repo)
+	standardService := services.NewStandardService(repo, repo, auditService)
+	templateService := services.NewChecklistTemplateService(repo, repo, repo)
+	programmeService := services.NewProgrammeService(repo, repo, repo, auditService)
+	findingService := services.NewFindingService(repo, repo, activityLog)
+	capaService := services.NewCAPAService(repo, repo, repo, repo, activityLog)
+	evidenceService := services.NewEvidenceService(repo, repo, repo, blobStore, cfg.EvidenceMaxBytes)
+	reportService := services.NewReportService(repo, repo, repo, repo, repo, report.Renderers())
+	guestService := services.NewGuestService(repo, auditService, jwtAdapter)
+	activityService := services.NewActivityService(repo, repo)
 
 	// 3. Adapters (Handlers)
 	authHandler := handlers.NewAuthHandler(authService)
 	orgHandler := handlers.NewOrganizationHandler(orgService)
 	auditHandler := handlers.NewAuditHandler(auditService)
+	planningHandler := handlers.NewPlanningHandler(planningService)
+	calendarHandler := handlers.NewCalendarHandler(calendarService)
+	programmeHandler := handlers.NewProgrammeHandler(programmeService)
+	standardHandler := handlers.NewStandardHandler(standardService)
+	templateHandler := handlers.NewChecklistTemplateHandler(templateService)
+	clientHandler := handlers.NewClientHandler(clientService)
+	auditeeHandler := handlers.NewAuditeeHandler(auditeeService)
+	findingHandler := handlers.NewFindingHandler(findingService)
+	capaHandler := handlers.NewCAPAHandler(capaService)
+	evidenceHandler := handlers.NewEvidenceHandler(evidenceService)
+	reportHandler := handlers.NewReportHandler(reportService)
+	guestHandler := handlers.NewGuestHandler(guestService, auditService, evidenceHandler)
+	activityHandler := handlers.NewActivityHandler(activityService)
 
 	// 4. Fiber App Setup
 	app := fiber.New(fiber.Config{
-		AppName: "ISO Stack API v1.0",
+		AppName:   "ISO Stack API v1.0",
+		BodyLimit: int(cfg.EvidenceMaxBytes) + 1<<20, // Margen para los campos multipart
 	})
 
-	app.Use(logger.New())
+	app.Use(requestid.New())
+	// El límite ampliado es solo para subir evidencia; el resto conserva el de Fiber
+	app.Use(handlers.BodyLimit(fiber.DefaultBodyLimit, isEvidenceUpload))
+	app.Use(logger.New(logger.Config{
+		Format: "${time} | ${status} | ${latency} | ${ip} | ${method} | ${path} | ${locals:requestid} | ${error}\n",
+	}))
+	app.Use(handlers.RequestContext(cfg.RequestTimeout))
 
 	api := app.Group("/api/v1")
 
@@ -44,28 +87,155 @@ func main() {
 	authGroup := api.Group("/auth")
 	authGroup.Post("/register", authHandler.Register)
 	authGroup.Post("/login", authHandler.Login)
+	authGroup.Post("/refresh", authHandler.Refresh)
 	authGroup.Post("/logout", handlers.AuthMiddleware(cfg.JWTSecret, repo), authHandler.Logout)
+	authGroup.Get("/organizations", handlers.AuthMiddleware(cfg.JWTSecret, repo), authHandler.ListOrganizations)
+	authGroup.Post("/switch-org", handlers.AuthMiddleware(cfg.JWTSecret, repo), authHandler.SwitchOrganization)
+	authGroup.Get("/sessions", handlers.AuthMiddleware(cfg.JWTSecret, repo), authHandler.ListSessions)
+	authGroup.Delete("/sessions/:session_id", handlers.AuthMiddleware(cfg.JWTSecret, repo), authHandler.RevokeSession)
 
 	// Organization Staff Routes
 	orgGroup := api.Group("/organization")
 	orgGroup.Use(handlers.AuthMiddleware(cfg.JWTSecret, repo))
-	orgGroup.Post("/staff/invite", orgHandler.InviteStaff)
-	orgGroup.Get("/staff", orgHandler.ListStaff)
-	orgGroup.Patch("/staff/status", orgHandler.UpdateStaffStatus)
+	orgGroup.Post("/staff/invite", handlers.RequirePermission(domain.ActionStaffInvite), orgHandler.InviteStaff)
+	orgGroup.Get("/staff", handlers.RequirePermission(domain.ActionStaffList), orgHandler.ListStaff)
+	orgGroup.Patch("/staff/status", handlers.RequirePermission(domain.ActionStaffUpdate), orgHandler.UpdateStaffStatus)
+	orgGroup.Get("/invitations", handlers.RequirePermission(domain.ActionStaffInvite), orgHandler.ListInvitations)
+	orgGroup.Post("/invitations/:invitation_id/resend", handlers.RequirePermission(domain.ActionStaffInvite), orgHandler.ResendInvitation)
+	orgGroup.Delete("/invitations/:invitation_id", handlers.RequirePermission(domain.ActionStaffInvite), orgHandler.RevokeInvitation)
+	orgGroup.Get("/report-template", reportHandler.GetTemplate)
+	orgGroup.Put("/report-template", handlers.RequirePermission(domain.ActionReportTemplate), reportHandler.SaveTemplate)
 
 	// Audit Routes
 	auditGroup := api.Group("/audits")
 	auditGroup.Use(handlers.AuthMiddleware(cfg.JWTSecret, repo))
-	auditGroup.Post("/", auditHandler.CreateAudit)
+	// Los permisos por auditoría (RoleInAudit) se evalúan en los servicios
+	auditGroup.Post("/", handlers.RequirePermission(domain.ActionAuditCreate), auditHandler.CreateAudit)
 	auditGroup.Post("/:audit_id/assign", auditHandler.AssignStaff)
+	auditGroup.Post("/:audit_id/assignment/accept", auditHandler.AcceptAssignment)
+	auditGroup.Post("/:audit_id/assignment/reject", auditHandler.RejectAssignment)
+	auditGroup.Get("/:audit_id/assignments", auditHandler.ListAssignments)
+	auditGroup.Post("/:audit_id/assignments/:user_id/reassign", auditHandler.ReassignStaff)
+	auditGroup.Get("/:audit_id/links", auditHandler.ListTemporaryLinks)
+	auditGroup.Post("/:audit_id/links", auditHandler.CreateTemporaryLink)
+	auditGroup.Delete("/:audit_id/links/:link_id", auditHandler.RevokeTemporaryLink)
+	auditGroup.Patch("/:audit_id/status", auditHandler.TransitionAudit)
+	auditGroup.Get("/:audit_id/status/history", auditHandler.GetStatusHistory)
+	auditGroup.Get("/:audit_id/checklist", auditHandler.GetChecklist)
+	auditGroup.Post("/:audit_id/checklist", auditHandler.AddChecklistItems)
+	auditGroup.Patch("/:audit_id/checklist/:item_id", auditHandler.AnswerChecklistItem)
+	auditGroup.Post("/:audit_id/checklist/from-standard", standardHandler.BuildChecklist)
+
+	// Planning Routes (plan de auditoría y agenda)
+	auditGroup.Get("/:audit_id/plan", planningHandler.GetPlan)
+	auditGroup.Put("/:audit_id/plan/schedule", planningHandler.SetSchedule)
+	auditGroup.Get("/:audit_id/plan/conflicts", planningHandler.ListConflicts)
+	auditGroup.Post("/:audit_id/plan/sessions", planningHandler.AddSession)
+	auditGroup.Put("/:audit_id/plan/sessions/:session_id", planningHandler.UpdateSession)
+	auditGroup.Delete("/:audit_id/plan/sessions/:session_id", planningHandler.DeleteSession)
+
+	// Finding Routes
+	auditGroup.Get("/:audit_id/findings", findingHandler.ListFindings)
+	auditGroup.Post("/:audit_id/findings", findingHandler.CreateFinding)
+	auditGroup.Get("/:audit_id/findings/:finding_id", findingHandler.GetFinding)
+	auditGroup.Put("/:audit_id/findings/:finding_id", findingHandler.UpdateFinding)
+	auditGroup.Delete("/:audit_id/findings/:finding_id", findingHandler.DeleteFinding)
+	auditGroup.Post("/:audit_id/findings/:finding_id/capa", capaHandler.CreateCAPA)
+	auditGroup.Get("/:audit_id/capas", capaHandler.ListAuditCAPAs)
+
+	// Evidence Routes
+	auditGroup.Get("/:audit_id/evidence", evidenceHandler.ListEvidence)
+	auditGroup.Post("/:audit_id/evidence", handlers.Deadline(cfg.UploadTimeout), evidenceHandler.UploadEvidence)
+	auditGroup.Get("/:audit_id/evidence/:evidence_id", evidenceHandler.DownloadEvidence)
+	auditGroup.Delete("/:audit_id/evidence/:evidence_id", evidenceHandler.DeleteEvidence)
+
+	// Report Routes
+	auditGroup.Get("/:audit_id/report", handlers.Deadline(cfg.UploadTimeout), reportHandler.GetReport)
+	auditGroup.Put("/:audit_id/conclusions", reportHandler.SetConclusions)
+
+	// Standards Catalogue Routes - catálogo global + normas privadas de la organización activa
+	standardGroup := api.Group("/standards")
+	standardGroup.Use(handlers.AuthMiddleware(cfg.JWTSecret, repo))
+	standardGroup.Get("/", standardHandler.ListStandards)
+	standardGroup.Get("/:standard_id", standardHandler.GetStandard)
+	standardGroup.Post("/import", handlers.RequirePermission(domain.ActionStandardManage), standardHandler.ImportStandard)
+	standardGroup.Delete("/:standard_id", handlers.RequirePermission(domain.ActionStandardManage), standardHandler.DeleteStandard)
+
+	// Checklist Template Routes - plantillas versionadas de la organización activa
+	templateGroup := api.Group("/checklist-templates")
+	templateGroup.Use(handlers.AuthMiddleware(cfg.JWTSecret, repo))
+	templateGroup.Get("/", templateHandler.ListTemplates)
+	templateGroup.Post("/", handlers.RequirePermission(domain.ActionTemplateManage), templateHandler.CreateTemplate)
+	templateGroup.Get("/:template_id", templateHandler.GetTemplate)
+	templateGroup.Get("/:template_id/versions", templateHandler.ListVersions)
+	templateGroup.Put("/:template_id", handlers.RequirePermission(domain.ActionTemplateManage), templateHandler.UpdateTemplate)
+	templateGroup.Post("/:template_id/clone", handlers.RequirePermission(domain.ActionTemplateManage), templateHandler.CloneTemplate)
+
+	// Client Routes - organizaciones auditadas por la consultora activa
+	clientGroup := api.Group("/clients")
+	clientGroup.Use(handlers.AuthMiddleware(cfg.JWTSecret, repo), handlers.RequirePermission(domain.ActionClientManage))
+	clientGroup.Get("/", clientHandler.ListClients)
+	clientGroup.Post("/", clientHandler.CreateClient)
+	clientGroup.Get("/:client_id", clientHandler.GetClient)
+	clientGroup.Put("/:client_id", clientHandler.UpdateClient)
+	clientGroup.Post("/:client_id/invitations", clientHandler.InviteClientUser)
+
+	// Auditee Routes - usuarios del cliente: solo auditorías realizadas sobre su organización
+	auditeeGroup := api.Group("/auditee")
+	auditeeGroup.Use(handlers.AuthMiddleware(cfg.JWTSecret, repo), handlers.RequirePermission(domain.ActionAuditeeRead))
+	auditeeGroup.Get("/audits", auditeeHandler.ListAudits)
+	auditeeGroup.Get("/audits/:audit_id", auditeeHandler.GetAudit)
+	auditeeGroup.Get("/audits/:audit_id/findings", auditeeHandler.ListFindings)
+
+	// Audit Programme Routes (programa plurianual de la organización activa)
+	programmeGroup := api.Group("/programmes")
+	programmeGroup.Use(handlers.AuthMiddleware(cfg.JWTSecret, repo))
+	programmeGroup.Get("/", handlers.RequirePermission(domain.ActionProgrammeRead), programmeHandler.ListProgrammes)
+	programmeGroup.Post("/", handlers.RequirePermission(domain.ActionProgrammeEdit), programmeHandler.CreateProgramme)
+	programmeGroup.Get("/:programme_id", handlers.RequirePermission(domain.ActionProgrammeRead), programmeHandler.GetProgramme)
+	programmeGroup.Put("/:programme_id", handlers.RequirePermission(domain.ActionProgrammeEdit), programmeHandler.UpdateProgramme)
+	programmeGroup.Get("/:programme_id/coverage", handlers.RequirePermission(domain.ActionProgrammeRead), programmeHandler.Coverage)
+	programmeGroup.Get("/:programme_id/entries", handlers.RequirePermission(domain.ActionProgrammeRead), programmeHandler.ListEntries)
+	programmeGroup.Post("/:programme_id/entries", handlers.RequirePermission(domain.ActionProgrammeEdit), programmeHandler.AddEntry)
+	programmeGroup.Delete("/:programme_id/entries/:entry_id", handlers.RequirePermission(domain.ActionProgrammeEdit), programmeHandler.DeleteEntry)
+	programmeGroup.Post("/:programme_id/entries/:entry_id/audit", handlers.RequirePermission(domain.ActionProgrammeEdit), programmeHandler.SpawnAudit)
+
+	// Corrective Action (CAPA) Routes - compartidas entre consultora y organización auditada
+	capaGroup := api.Group("/capas")
+	capaGroup.Use(handlers.AuthMiddleware(cfg.JWTSecret, repo))
+	capaGroup.Get("/", handlers.RequirePermission(domain.ActionCAPARead), capaHandler.ListOrgCAPAs)
+	capaGroup.Get("/:capa_id", capaHandler.GetCAPA)
+	capaGroup.Post("/:capa_id/propose", capaHandler.ProposeCAPA)
+	capaGroup.Patch("/:capa_id/status", capaHandler.TransitionCAPA)
+
+	// Activity Routes - bitácora inmutable de la organización activa
+	activityGroup := api.Group("/activity")
+	activityGroup.Use(handlers.AuthMiddleware(cfg.JWTSecret, repo), handlers.RequirePermission(domain.ActionActivityRead))
+	activityGroup.Get("/", activityHandler.ListActivity)
+	activityGroup.Get("/verify", activityHandler.VerifyChain)
 
 	// Project Routes
 	projectGroup := api.Group("/projects")
 	projectGroup.Use(handlers.AuthMiddleware(cfg.JWTSecret, repo))
 	projectGroup.Get("/my-audits", auditHandler.GetMyAudits)
 
+	// Calendar Routes - la suscripción se gestiona con sesión; el feed .ics es público por token
+	calendarGroup := api.Group("/calendar")
+	calendarGroup.Post("/feed", handlers.AuthMiddleware(cfg.JWTSecret, repo), calendarHandler.EnableFeed)
+	calendarGroup.Delete("/feed", handlers.AuthMiddleware(cfg.JWTSecret, repo), calendarHandler.DisableFeed)
+	calendarGroup.Get("/:token.ics", calendarHandler.GetFeed)
+
 	// Public Access
-	api.Get("/public/access/:temp_link", auditHandler.GetPublicAudit)
+	api.Post("/invitations/accept", orgHandler.AcceptInvitation)
+	api.Get("/public/access/:temp_link", guestHandler.OpenLink)
+	api.Post("/public/access/:temp_link/session", guestHandler.StartSession)
+
+	// Guest Routes - participantes externos con token emitido desde un enlace temporal
+	guestGroup := api.Group("/guest")
+	guestGroup.Use(handlers.GuestMiddleware(cfg.JWTSecret, repo))
+	guestGroup.Get("/checklist", handlers.RequireScope(domain.ScopeView), guestHandler.GetChecklist)
+	guestGroup.Patch("/checklist/:item_id", handlers.RequireScope(domain.ScopeAnswerChecklist), guestHandler.AnswerChecklistItem)
+	guestGroup.Post("/evidence", handlers.RequireScope(domain.ScopeUploadEvidence), handlers.Deadline(cfg.UploadTimeout), guestHandler.UploadEvidence)
 
 	// Debug Routes Info
 	fmt.Println("\n--- RUTAS REGISTRADAS ---")
@@ -78,3 +248,63 @@ func main() {
 	log.Printf("Iniciando servidor en puerto %s...", cfg.Port)
 	log.Fatal(app.Listen(":" + cfg.Port))
 }
+
+// isEvidenceUpload identifica las rutas de subida de evidencia (equipo e invitados)
+func isEvidenceUpload(c *fiber.Ctx) bool {
+	return c.Method() == fiber.MethodPost && strings.HasSuffix(c.Path(), "/evidence")
+}
+
+// newRepository elige el backend de base de datos según DB_DRIVER
+func newRepository(cfg *config.Config) ports.Repositories {
+	switch cfg.DBDriver {
+	case "postgres":
+		return repository.NewPostgresDB(cfg.DBDSN, cfg.DBAutoMigrate)
+	case "sqlite":
+		return repository.NewSQLiteDB(cfg.SQLitePath, cfg.DBAutoMigrate)
+	default:
+		log.Fatalf("DB_DRIVER desconocido: %s", cfg.DBDriver)
+		return nil
+	}
+}
+
+// newBlobStore elige el almacenamiento de evidencias según BLOB_DRIVER
+func newBlobStore(cfg *config.Config) ports.BlobStore {
+	switch cfg.BlobDriver {
+	case "s3":
+		store, err := storage.NewS3BlobStore(cfg.S3Endpoint, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3Bucket, cfg.S3UseSSL)
+		if err != nil {
+			log.Fatal("No se pudo inicializar el almacenamiento S3: ", err)
+		}
+		return store
+	case "local":
+		store, err := storage.NewLocalBlobStore(cfg.BlobLocalDir)
+		if err != nil {
+			log.Fatal("No se pudo inicializar el almacenamiento local: ", err)
+		}
+		return store
+	default:
+		log.Fatalf("BLOB_DRIVER desconocido: %s", cfg.BlobDriver)
+		return nil
+	}
+}
+
+// newNotifier elige el canal de notificaciones según NOTIFIER
+func newNotifier(cfg *config.Config) ports.Notifier {
+	switch cfg.Notifier {
+	case "smtp":
+		return &notify.SMTPNotifier{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			User:     cfg.SMTPUser,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}
+	case "noop":
+		return notify.NoopNotifier{}
+	case "log":
+		return notify.LogNotifier{}
+	default:
+		log.Fatalf("NOTIFIER desconocido: %s", cfg.Notifier)
+		return nil
+	}
+}
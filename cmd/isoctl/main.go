@@ -0,0 +1,268 @@
+// isoctl es la herramienta de operador: alta y reparación de datos sin escribir SQL.
+// Usa la misma configuración (.env) y los mismos servicios que la API.
+//
+//	isoctl org create -name "Consultora X" -email admin@x.com [-password ...]
+//	isoctl user reset-password -email a@x.com [-password ...]
+//	isoctl user set-role -email a@x.com -org <org_id> -role Auditor_Lider
+//	isoctl user revoke-tokens -email a@x.com
+//	isoctl tokens purge
+//	isoctl audits list -org <org_id>
+//	isoctl standards import iso9001.json
+//	isoctl standards import iso14001.csv -code "ISO 14001" -version 2015 -title "..."
+//
+// Sin -password, la contraseña se lee de la entrada estándar (no queda en el historial).
+// -o json cambia la salida en tabla por JSON.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/catalog"
+	"github.com/RiosHectorM/iso-stack/internal/adapters/repository"
+	"github.com/RiosHectorM/iso-stack/internal/config"
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/RiosHectorM/iso-stack/internal/core/services"
+	"github.com/google/uuid"
+	"gorm.io/gorm/logger"
+)
+
+const usage = `uso: isoctl <comando> [flags]
+
+  org create       -name -email [-password]   crea una organización con su usuario Consultora
+  user reset-password -email [-password]      cambia la contraseña y cierra las sesiones
+  user set-role    -email -org -role          cambia el rol del usuario en la organización
+  user revoke-tokens -email                   cierra todas las sesiones del usuario
+  tokens purge                                borra los tokens revocados ya vencidos
+  audits list      -org                       lista las auditorías de la organización
+  standards import <archivo> [-code -version -title]
+                                              carga una norma en el catálogo global (JSON o CSV;
+                                              el CSV necesita -code, -version y -title)
+
+Todos los comandos aceptan -o table|json.`
+
+func main() {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command := os.Args[1] + " " + os.Args[2]
+
+	flags := flag.NewFlagSet("isoctl "+command, flag.ExitOnError)
+	output := flags.String("o", "table", "formato de salida: table o json")
+	name := flags.String("name", "", "nombre de la organización")
+	email := flags.String("email", "", "email del usuario")
+	password := flags.String("password", "", "contraseña (vacío = leer de la entrada estándar)")
+	orgID := flags.String("org", "", "ID de la organización")
+	role := flags.String("role", "", "rol: Consultora, Auditor_Lider, Auditor_Interno, Auxiliar, Observador o Cliente")
+	code := flags.String("code", "", "código de la norma (CSV)")
+	version := flags.String("version", "", "versión de la norma (CSV)")
+	title := flags.String("title", "", "título de la norma (CSV)")
+	flags.Parse(os.Args[3:])
+	// Los argumentos posicionales pueden ir antes de los flags: "standards import x.csv -code ..."
+	var args []string
+	for flags.NArg() > 0 {
+		args = append(args, flags.Arg(0))
+		flags.Parse(flags.Args()[1:])
+	}
+	if *output != "table" && *output != "json" {
+		log.Fatal("-o debe ser table o json")
+	}
+
+	cfg := config.LoadConfig()
+	repo := openRepository(cfg)
+	activity := services.NewActivityLog(repo)
+	admin := services.NewAdminService(repo, repo, repo, cfg.AccessTokenTTL, activity)
+
+	// La bitácora registra las acciones de isoctl con su propio ID de petición
+	ctx := domain.WithRequestMeta(context.Background(), domain.RequestMeta{RequestID: "isoctl-" + uuid.New().String()})
+
+	out := printer{format: *output}
+	var err error
+	switch command {
+	case "org create":
+		require(map[string]string{"name": *name, "email": *email})
+		var org *domain.Organization
+		var user *domain.User
+		org, user, err = admin.CreateOrganization(ctx, *name, *email, readPassword(*password))
+		if err == nil {
+			out.print(map[string]string{"organization_id": org.ID, "name": org.Name, "user_id": user.ID, "email": user.Email},
+				[]string{"ORGANIZATION_ID", "NAME", "USER_ID", "EMAIL"}, [][]string{{org.ID, org.Name, user.ID, user.Email}})
+		}
+	case "user reset-password":
+		require(map[string]string{"email": *email})
+		if err = admin.ResetPassword(ctx, *email, readPassword(*password)); err == nil {
+			out.message("Contraseña actualizada para " + *email)
+		}
+	case "user set-role":
+		require(map[string]string{"email": *email, "org": *orgID, "role": *role})
+		if err = admin.ChangeRole(ctx, *email, *orgID, domain.Role(*role)); err == nil {
+			out.message(fmt.Sprintf("%s ahora es %s en %s", *email, *role, *orgID))
+		}
+	case "user revoke-tokens":
+		require(map[string]string{"email": *email})
+		var count int
+		if count, err = admin.RevokeTokens(ctx, *email); err == nil {
+			out.print(map[string]int{"revoked_sessions": count}, []string{"REVOKED_SESSIONS"}, [][]string{{fmt.Sprint(count)}})
+		}
+	case "tokens purge":
+		var count int64
+		if count, err = admin.PurgeRevokedTokens(ctx); err == nil {
+			out.print(map[string]int64{"purged": count}, []string{"PURGED"}, [][]string{{fmt.Sprint(count)}})
+		}
+	case "audits list":
+		require(map[string]string{"org": *orgID})
+		var audits []domain.Audit
+		if audits, err = admin.ListAudits(ctx, *orgID); err == nil {
+			rows := make([][]string, 0, len(audits))
+			for _, a := range audits {
+				rows = append(rows, []string{a.ID, a.Title, string(a.Status), formatDate(a.PlannedStart), a.CreatedAt.Format("2006-01-02")})
+			}
+			if audits == nil {
+				audits = []domain.Audit{}
+			}
+			out.print(audits, []string{"ID", "TITLE", "STATUS", "PLANNED_START", "CREATED"}, rows)
+		}
+	case "standards import":
+		if len(args) != 1 {
+			log.Fatal("uso: isoctl standards import <archivo> [-code -version -title]")
+		}
+		standard, clauses := readStandard(args[0], &domain.Standard{Code: *code, Version: *version, Title: *title})
+		// Sin servicio de auditorías: la herramienta solo importa, no arma checklists
+		standards := services.NewStandardService(repo, repo, nil)
+		var detail *domain.StandardDetail
+		if detail, err = standards.ImportStandard(ctx, "", "", standard, clauses); err == nil {
+			out.print(detail.Standard, []string{"ID", "STANDARD", "TITLE", "CLAUSES"},
+				[][]string{{detail.ID, detail.Label(), detail.Title, fmt.Sprint(len(clauses))}})
+		}
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Fatal("no encontrado: revisá el email o el ID de organización")
+		}
+		log.Fatal(err)
+	}
+}
+
+// openRepository abre la base configurada (aplicando las migraciones pendientes, como la API)
+func openRepository(cfg *config.Config) ports.Repositories {
+	// Los avisos de GORM van a stderr: stdout queda solo para la salida del comando
+	logger.Default = logger.New(log.New(os.Stderr, "", log.LstdFlags), logger.Config{
+		SlowThreshold:             200 * time.Millisecond,
+		LogLevel:                  logger.Warn,
+		IgnoreRecordNotFoundError: true,
+	})
+
+	var repo ports.Repositories
+	var err error
+	switch cfg.DBDriver {
+	case "postgres":
+		repo, err = repository.OpenPostgres(cfg.DBDSN, cfg.DBAutoMigrate)
+	case "sqlite":
+		repo, err = repository.OpenSQLite(cfg.SQLitePath, cfg.DBAutoMigrate)
+	default:
+		err = fmt.Errorf("DB_DRIVER desconocido: %s", cfg.DBDriver)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	return repo
+}
+
+// readStandard lee una norma del archivo según su extensión. Para CSV, los datos de la
+// norma vienen de los flags porque el archivo solo trae las cláusulas.
+func readStandard(path string, header *domain.Standard) (*domain.Standard, []domain.Clause) {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	var standard *domain.Standard
+	var clauses []domain.Clause
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		standard, clauses, err = catalog.ParseJSON(file)
+	case ".csv":
+		require(map[string]string{"code": header.Code, "version": header.Version, "title": header.Title})
+		standard, clauses, err = catalog.ParseCSV(file, header)
+	default:
+		log.Fatal("el archivo debe ser .json o .csv")
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	return standard, clauses
+}
+
+func require(values map[string]string) {
+	for flagName, value := range values {
+		if strings.TrimSpace(value) == "" {
+			log.Fatalf("falta -%s", flagName)
+		}
+	}
+}
+
+func readPassword(password string) string {
+	if password != "" {
+		return password
+	}
+	fmt.Fprint(os.Stderr, "Contraseña: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		log.Fatal("no se pudo leer la contraseña: ", err)
+	}
+	return strings.TrimRight(line, "\r\n")
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
+
+type printer struct {
+	format string
+}
+
+// print escribe v como JSON, o las filas como tabla alineada
+func (p printer) print(v interface{}, header []string, rows [][]string) {
+	if p.format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+}
+
+func (p printer) message(text string) {
+	if p.format == "json" {
+		p.print(map[string]string{"message": text}, nil, nil)
+		return
+	}
+	fmt.Println(text)
+}
@@ -0,0 +1,92 @@
+// migrate aplica o revierte las migraciones versionadas de la base configurada (DB_DRIVER).
+//
+//	go run ./cmd/migrate up         aplica las pendientes
+//	go run ./cmd/migrate down [n]   revierte las últimas n (1 por defecto)
+//	go run ./cmd/migrate status     lista aplicadas y pendientes
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/repository"
+	"github.com/RiosHectorM/iso-stack/internal/config"
+	"gorm.io/gorm"
+)
+
+const usage = "uso: migrate up | down [n] | status"
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+	cfg := config.LoadConfig()
+
+	db, err := connect(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	migrator, err := repository.NewMigrator(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch os.Args[1] {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			fmt.Printf("aplicada   %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("No hay migraciones pendientes")
+		}
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps < 1 {
+				log.Fatal("n debe ser un entero positivo")
+			}
+		}
+		reverted, err := migrator.Down(steps)
+		for _, m := range reverted {
+			fmt.Printf("revertida  %04d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, m := range status {
+			state := "pendiente"
+			if m.AppliedAt != nil {
+				state = "aplicada " + m.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if m.Unknown {
+				state += " (desconocida para este binario)"
+			}
+			fmt.Printf("%04d_%-30s %s\n", m.Version, m.Name, state)
+		}
+	default:
+		log.Fatal(usage)
+	}
+}
+
+func connect(cfg *config.Config) (*gorm.DB, error) {
+	switch cfg.DBDriver {
+	case "postgres":
+		return repository.ConnectPostgres(cfg.DBDSN)
+	case "sqlite":
+		return repository.ConnectSQLite(cfg.SQLitePath)
+	default:
+		return nil, fmt.Errorf("DB_DRIVER desconocido: %s", cfg.DBDriver)
+	}
+}
@@ -6,8 +6,12 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// DefaultAccessTTL se usa si no se configura AccessTTL
+const DefaultAccessTTL = 15 * time.Minute
+
 type JWTAdapter struct {
-	Secret string
+	Secret    string
+	AccessTTL time.Duration
 }
 
 type CustomClaims struct {
@@ -17,13 +21,49 @@ type CustomClaims struct {
 	jwt.RegisteredClaims
 }
 
-func (j *JWTAdapter) GenerateToken(userID, orgID, role string) (string, error) {
+func (j *JWTAdapter) TTL() time.Duration {
+	if j.AccessTTL <= 0 {
+		return DefaultAccessTTL
+	}
+	return j.AccessTTL
+}
+
+// GenerateToken emite un access token de vida corta; sessionID viaja como jti
+func (j *JWTAdapter) GenerateToken(userID, orgID, role, sessionID string) (string, error) {
 	claims := CustomClaims{
 		UserID: userID,
 		OrgID:  orgID,
 		Role:   role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24)),
+			ID:        sessionID,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.TTL())),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(j.Secret))
+}
+
+// GuestClaims identifican a un participante externo que entró por un enlace temporal.
+// El jti es el ID del enlace, para poder cortar la sesión al revocarlo.
+type GuestClaims struct {
+	UserID  string   `json:"user_id"`
+	AuditID string   `json:"audit_id"`
+	Scopes  []string `json:"scopes"`
+	Guest   bool     `json:"guest"`
+	jwt.RegisteredClaims
+}
+
+func (j *JWTAdapter) GenerateGuestToken(userID, auditID, linkID string, scopes []string, expiresAt time.Time) (string, error) {
+	claims := GuestClaims{
+		UserID:  userID,
+		AuditID: auditID,
+		Scopes:  scopes,
+		Guest:   true,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        linkID,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -0,0 +1,76 @@
+// Package calendar serializa la agenda de auditorías a iCalendar (RFC 5545)
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+const ContentType = "text/calendar; charset=utf-8"
+
+const timeFormat = "20060102T150405Z"
+
+// WriteICS escribe un VCALENDAR con los eventos del feed
+func WriteICS(w io.Writer, name string, events []domain.CalendarEvent) error {
+	bw := bufio.NewWriter(w)
+	line := func(s string) { writeFolded(bw, s) }
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//ISO Stack//Audit Calendar//ES")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:" + escape(name))
+	// Sugerencia de frecuencia de sincronización para los clientes suscritos
+	line("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	line("X-PUBLISHED-TTL:PT1H")
+
+	stamp := time.Now().UTC().Format(timeFormat)
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.UID)
+		line("DTSTAMP:" + stamp)
+		line("DTSTART:" + e.Start.UTC().Format(timeFormat))
+		line("DTEND:" + e.End.UTC().Format(timeFormat))
+		line("SUMMARY:" + escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:" + escape(e.Description))
+		}
+		if e.Location != "" {
+			line("LOCATION:" + escape(e.Location))
+		}
+		line("STATUS:" + string(e.Status))
+		line("SEQUENCE:" + strconv.Itoa(e.Sequence))
+		line("LAST-MODIFIED:" + e.LastModified.UTC().Format(timeFormat))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return bw.Flush()
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// writeFolded corta las líneas de más de 75 octetos sin partir caracteres UTF-8
+func writeFolded(w *bufio.Writer, s string) {
+	const limit = 75
+	width := 0
+	for _, r := range s {
+		size := len(string(r))
+		if width+size > limit {
+			w.WriteString("\r\n ")
+			width = 1
+		}
+		w.WriteRune(r)
+		width += size
+	}
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,108 @@
+// Package catalog lee normas del catálogo desde archivos de intercambio (JSON, CSV)
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+type jsonClause struct {
+	Number           string       `json:"number"`
+	Parent           string       `json:"parent"` // Opcional si la cláusula viene anidada en children
+	Title            string       `json:"title"`
+	Requirement      string       `json:"requirement"`
+	ExpectedEvidence string       `json:"expected_evidence"`
+	Children         []jsonClause `json:"children"`
+}
+
+type jsonStandard struct {
+	Code    string       `json:"code"`
+	Version string       `json:"version"`
+	Title   string       `json:"title"`
+	Clauses []jsonClause `json:"clauses"`
+}
+
+// ParseJSON lee una norma con sus cláusulas, planas (con "parent") o anidadas en "children":
+//
+//	{"code": "ISO 9001", "version": "2015", "title": "...",
+//	 "clauses": [{"number": "7", "title": "Apoyo", "children": [{"number": "7.1", ...}]}]}
+func ParseJSON(r io.Reader) (*domain.Standard, []domain.Clause, error) {
+	var doc jsonStandard
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("%w: malformed JSON: %v", domain.ErrInvalidInput, err)
+	}
+
+	var clauses []domain.Clause
+	var flatten func(parent string, level []jsonClause)
+	flatten = func(parent string, level []jsonClause) {
+		for _, c := range level {
+			if c.Parent == "" {
+				c.Parent = parent
+			}
+			clauses = append(clauses, domain.Clause{
+				Number:           c.Number,
+				ParentNumber:     c.Parent,
+				Title:            c.Title,
+				Requirement:      c.Requirement,
+				ExpectedEvidence: c.ExpectedEvidence,
+			})
+			flatten(c.Number, c.Children)
+		}
+	}
+	flatten("", doc.Clauses)
+
+	return &domain.Standard{Code: doc.Code, Version: doc.Version, Title: doc.Title}, clauses, nil
+}
+
+// ParseCSV lee las cláusulas de un CSV con encabezado. Columnas reconocidas:
+// number (obligatoria), parent, title, requirement, expected_evidence.
+// Los datos de la norma no viajan en el CSV: los aporta quien llama.
+func ParseCSV(r io.Reader, standard *domain.Standard) (*domain.Standard, []domain.Clause, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: empty or malformed CSV", domain.ErrInvalidInput)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Excel agrega BOM al exportar CSV en UTF-8
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["number"]; !ok {
+		return nil, nil, fmt.Errorf("%w: CSV must have a \"number\" column", domain.ErrInvalidInput)
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var clauses []domain.Clause
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: CSV line %d: %v", domain.ErrInvalidInput, line, err)
+		}
+		clauses = append(clauses, domain.Clause{
+			Number:           field(record, "number"),
+			ParentNumber:     field(record, "parent"),
+			Title:            field(record, "title"),
+			Requirement:      field(record, "requirement"),
+			ExpectedEvidence: field(record, "expected_evidence"),
+		})
+	}
+	return standard, clauses, nil
+}
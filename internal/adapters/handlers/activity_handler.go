@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ActivityHandler struct {
+	service ports.ActivityService
+}
+
+func NewActivityHandler(service ports.ActivityService) *ActivityHandler {
+	return &ActivityHandler{service: service}
+}
+
+// ListActivity filtra por entity_type, entity_id, actor_id, action, request_id y el rango
+// from/to (RFC 3339). Para paginar se pasa before_seq con el seq del último registro recibido.
+func (h *ActivityHandler) ListActivity(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	orgID := c.Locals("org_id").(string)
+
+	filter := domain.ActivityFilter{
+		EntityType: domain.ActivityEntity(c.Query("entity_type")),
+		EntityID:   c.Query("entity_id"),
+		ActorID:    c.Query("actor_id"),
+		Action:     domain.ActivityAction(c.Query("action")),
+		RequestID:  c.Query("request_id"),
+		BeforeSeq:  int64(c.QueryInt("before_seq")),
+		Limit:      c.QueryInt("limit"),
+	}
+	for name, dst := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := c.Query(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return c.Status(400).JSON(fiber.Map{"error": name + " must be an RFC 3339 date"})
+			}
+			*dst = &t
+		}
+	}
+
+	entries, err := h.service.ListActivity(c.UserContext(), orgID, userID, filter)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(entries)
+}
+
+// VerifyChain recalcula la cadena de hashes de la organización
+func (h *ActivityHandler) VerifyChain(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	orgID := c.Locals("org_id").(string)
+
+	result, err := h.service.VerifyChain(c.UserContext(), orgID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(result)
+}
@@ -1,6 +1,9 @@
 package handlers
 
 import (
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
 	"github.com/RiosHectorM/iso-stack/internal/core/ports"
 	"github.com/gofiber/fiber/v2"
 )
@@ -15,7 +18,9 @@ func NewAuditHandler(service ports.AuditService) *AuditHandler {
 
 func (h *AuditHandler) CreateAudit(c *fiber.Ctx) error {
 	var req struct {
-		Title string `json:"title"`
+		Title      string `json:"title"`
+		ClientID   string `json:"client_id"`   // Opcional: cliente auditado
+		TemplateID string `json:"template_id"` // Opcional: plantilla de checklist a precargar
 	}
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
@@ -24,9 +29,9 @@ func (h *AuditHandler) CreateAudit(c *fiber.Ctx) error {
 	orgID := c.Locals("org_id").(string)
 	userID := c.Locals("user_id").(string)
 
-	audit, err := h.service.CreateAudit(req.Title, orgID, userID)
+	audit, err := h.service.CreateAudit(c.UserContext(), req.Title, orgID, userID, req.ClientID, req.TemplateID)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+		return respondError(c, err)
 	}
 
 	return c.Status(201).JSON(audit)
@@ -44,9 +49,10 @@ func (h *AuditHandler) AssignStaff(c *fiber.Ctx) error {
 
 	auditID := c.Params("audit_id")
 	orgID := c.Locals("org_id").(string)
+	actorID := c.Locals("user_id").(string)
 
-	if err := h.service.AssignStaff(auditID, req.UserID, req.RoleInAudit, orgID); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	if err := h.service.AssignStaff(c.UserContext(), auditID, actorID, req.UserID, req.RoleInAudit, orgID); err != nil {
+		return respondError(c, err)
 	}
 
 	return c.Status(201).JSON(fiber.Map{"message": "staff assigned"})
@@ -54,18 +60,214 @@ func (h *AuditHandler) AssignStaff(c *fiber.Ctx) error {
 
 func (h *AuditHandler) GetMyAudits(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(string)
-	audits, err := h.service.GetMyAudits(userID)
+	acceptance := domain.AcceptanceStatus(c.Query("acceptance")) // Opcional: Pendiente, Aceptado, Rechazado
+	audits, err := h.service.GetMyAudits(c.UserContext(), userID, acceptance)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 	}
 	return c.JSON(audits)
 }
 
-func (h *AuditHandler) GetPublicAudit(c *fiber.Ctx) error {
-	tempLink := c.Params("temp_link")
-	audit, err := h.service.GetPublicAudit(tempLink)
+// --- Temporary links ---
+
+// CreateTemporaryLink emite un enlace para un participante externo ya asignado.
+// Sin scopes se usan los del rol en la auditoría; ttl_hours y max_uses son opcionales.
+func (h *AuditHandler) CreateTemporaryLink(c *fiber.Ctx) error {
+	var req struct {
+		UserID   string             `json:"user_id"`
+		Scopes   []domain.LinkScope `json:"scopes"`
+		TTLHours int                `json:"ttl_hours"`
+		MaxUses  int                `json:"max_uses"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.UserID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	actorID := c.Locals("user_id").(string)
+	orgID := c.Locals("org_id").(string)
+
+	link, url, err := h.service.CreateTemporaryLink(c.UserContext(), auditID, actorID, orgID, req.UserID, req.Scopes, time.Duration(req.TTLHours)*time.Hour, req.MaxUses)
+	if err != nil {
+		return respondError(c, err)
+	}
+	// La URL contiene el token en claro: solo se devuelve en este momento
+	return c.Status(201).JSON(fiber.Map{"link": link, "url": url})
+}
+
+func (h *AuditHandler) ListTemporaryLinks(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	actorID := c.Locals("user_id").(string)
+	orgID := c.Locals("org_id").(string)
+
+	links, err := h.service.ListTemporaryLinks(c.UserContext(), auditID, actorID, orgID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(links)
+}
+
+func (h *AuditHandler) RevokeTemporaryLink(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	linkID := c.Params("link_id")
+	actorID := c.Locals("user_id").(string)
+	orgID := c.Locals("org_id").(string)
+
+	if err := h.service.RevokeTemporaryLink(c.UserContext(), auditID, linkID, actorID, orgID); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "link revoked"})
+}
+
+// --- Assignments ---
+
+func (h *AuditHandler) AcceptAssignment(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	assignment, err := h.service.RespondToAssignment(c.UserContext(), auditID, userID, true, "")
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(assignment)
+}
+
+func (h *AuditHandler) RejectAssignment(c *fiber.Ctx) error {
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	assignment, err := h.service.RespondToAssignment(c.UserContext(), auditID, userID, false, req.Reason)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(assignment)
+}
+
+// ListAssignments admite ?acceptance=Pendiente para ver solo las respuestas pendientes
+func (h *AuditHandler) ListAssignments(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+	acceptance := domain.AcceptanceStatus(c.Query("acceptance"))
+
+	assignments, err := h.service.ListAssignments(c.UserContext(), auditID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	result := make([]domain.AuditAssignment, 0, len(assignments))
+	for _, a := range assignments {
+		if acceptance == "" || a.AcceptanceStatus == acceptance {
+			result = append(result, a)
+		}
+	}
+	return c.JSON(result)
+}
+
+func (h *AuditHandler) ReassignStaff(c *fiber.Ctx) error {
+	var req struct {
+		UserID string `json:"user_id"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.UserID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	declinedUserID := c.Params("user_id")
+	actorID := c.Locals("user_id").(string)
+	orgID := c.Locals("org_id").(string)
+
+	if err := h.service.ReassignStaff(c.UserContext(), auditID, actorID, declinedUserID, req.UserID, orgID); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "staff reassigned"})
+}
+
+// --- Checklist ---
+
+func (h *AuditHandler) GetChecklist(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	items, err := h.service.GetChecklist(c.UserContext(), auditID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(items)
+}
+
+func (h *AuditHandler) AddChecklistItems(c *fiber.Ctx) error {
+	var req struct {
+		Items []domain.ChecklistItem `json:"items"`
+	}
+	if err := c.BodyParser(&req); err != nil || len(req.Items) == 0 {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	items, err := h.service.AddChecklistItems(c.UserContext(), auditID, userID, req.Items)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(items)
+}
+
+func (h *AuditHandler) AnswerChecklistItem(c *fiber.Ctx) error {
+	var req struct {
+		Answer string `json:"answer"`
+		Notes  string `json:"notes"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	itemID := c.Params("item_id")
+	userID := c.Locals("user_id").(string)
+
+	item, err := h.service.AnswerChecklistItem(c.UserContext(), auditID, itemID, userID, domain.ChecklistAnswer(req.Answer), req.Notes)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(item)
+}
+
+// --- Lifecycle ---
+
+func (h *AuditHandler) TransitionAudit(c *fiber.Ctx) error {
+	var req struct {
+		Status string `json:"status"`
+		Reason string `json:"reason"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	audit, err := h.service.TransitionAudit(c.UserContext(), auditID, userID, domain.AuditStatus(req.Status), req.Reason)
 	if err != nil {
-		return c.Status(403).JSON(fiber.Map{"error": "invalid or expired link"})
+		return respondError(c, err)
 	}
 	return c.JSON(audit)
 }
+
+func (h *AuditHandler) GetStatusHistory(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	history, err := h.service.GetStatusHistory(c.UserContext(), auditID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(history)
+}
@@ -1,8 +1,7 @@
 package handlers
 
 import (
-	"strings"
-
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
 	"github.com/RiosHectorM/iso-stack/internal/core/ports"
 	"github.com/gofiber/fiber/v2"
 )
@@ -15,6 +14,13 @@ func NewAuthHandler(service ports.AuthService) *AuthHandler {
 	return &AuthHandler{Service: service}
 }
 
+func clientInfo(c *fiber.Ctx) domain.ClientInfo {
+	return domain.ClientInfo{
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		IP:        c.IP(),
+	}
+}
+
 func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	var req struct {
 		OrgName  string `json:"org_name"`
@@ -26,7 +32,7 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
 	}
 
-	token, err := h.Service.Register(req.Email, req.Password, req.OrgName)
+	tokens, err := h.Service.Register(c.UserContext(), req.Email, req.Password, req.OrgName, clientInfo(c))
 	if err != nil {
 		if err.Error() == "el usuario ya existe" {
 			return c.Status(409).JSON(fiber.Map{"error": err.Error()})
@@ -34,38 +40,118 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 		return c.Status(500).JSON(fiber.Map{"error": "could not create account"})
 	}
 
-	return c.Status(201).JSON(fiber.Map{"token": token})
+	return c.Status(201).JSON(tokens)
 }
 
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	var req struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
+		OrgID    string `json:"org_id"` // Opcional
 	}
 
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
 	}
 
-	token, err := h.Service.Login(req.Email, req.Password)
+	tokens, err := h.Service.Login(c.UserContext(), req.Email, req.Password, req.OrgID, clientInfo(c))
 	if err != nil {
 		return c.Status(401).JSON(fiber.Map{"error": "unauthorized"})
 	}
 
-	return c.JSON(fiber.Map{"token": token})
+	return c.JSON(tokens)
 }
 
 func (h *AuthHandler) Logout(c *fiber.Ctx) error {
-	authHeader := c.Get("Authorization")
-	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	sessionID, _ := c.Locals("session_id").(string)
 
-	if tokenString == "" {
+	if sessionID == "" {
 		return c.Status(400).JSON(fiber.Map{"error": "no hay token para invalidar"})
 	}
 
-	if err := h.Service.Logout(tokenString); err != nil {
+	if err := h.Service.Logout(c.UserContext(), sessionID); err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": "error al cerrar sesión"})
 	}
 
 	return c.JSON(fiber.Map{"message": "sesión cerrada exitosamente"})
 }
+
+func (h *AuthHandler) ListOrganizations(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	memberships, err := h.Service.ListOrganizations(c.UserContext(), userID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+	return c.JSON(memberships)
+}
+
+func (h *AuthHandler) SwitchOrganization(c *fiber.Ctx) error {
+	var req struct {
+		OrgID string `json:"org_id"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.OrgID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	userID := c.Locals("user_id").(string)
+	sessionID, _ := c.Locals("session_id").(string)
+
+	tokens, err := h.Service.SwitchOrganization(c.UserContext(), userID, req.OrgID, sessionID, clientInfo(c))
+	if err != nil {
+		return c.Status(403).JSON(fiber.Map{"error": "no es miembro activo de la organización"})
+	}
+	return c.JSON(tokens)
+}
+
+// --- Sessions ---
+
+// Refresh es público: el refresh token es la credencial
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.RefreshToken == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	tokens, err := h.Service.Refresh(c.UserContext(), req.RefreshToken, clientInfo(c))
+	if err != nil {
+		return c.Status(401).JSON(fiber.Map{"error": "refresh token inválido o expirado"})
+	}
+	return c.JSON(tokens)
+}
+
+func (h *AuthHandler) ListSessions(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	current, _ := c.Locals("session_id").(string)
+
+	sessions, err := h.Service.ListSessions(c.UserContext(), userID)
+	if err != nil {
+		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	result := make([]fiber.Map, 0, len(sessions))
+	for _, s := range sessions {
+		result = append(result, fiber.Map{
+			"id":           s.ID,
+			"org_id":       s.OrganizationID,
+			"user_agent":   s.UserAgent,
+			"ip":           s.IP,
+			"created_at":   s.CreatedAt,
+			"last_used_at": s.LastUsedAt,
+			"expires_at":   s.ExpiresAt,
+			"current":      s.ID == current,
+		})
+	}
+	return c.JSON(result)
+}
+
+func (h *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	if err := h.Service.RevokeSession(c.UserContext(), userID, c.Params("session_id")); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "sesión revocada"})
+}
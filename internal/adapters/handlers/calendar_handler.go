@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"github.com/RiosHectorM/iso-stack/internal/adapters/calendar"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CalendarHandler struct {
+	service ports.CalendarService
+}
+
+func NewCalendarHandler(service ports.CalendarService) *CalendarHandler {
+	return &CalendarHandler{service: service}
+}
+
+// EnableFeed genera la URL de suscripción; llamarlo de nuevo la regenera
+func (h *CalendarHandler) EnableFeed(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	token, err := h.service.EnableFeed(c.UserContext(), userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(fiber.Map{"url": c.BaseURL() + "/api/v1/calendar/" + token + ".ics"})
+}
+
+func (h *CalendarHandler) DisableFeed(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	if err := h.service.DisableFeed(c.UserContext(), userID); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "calendar feed disabled"})
+}
+
+// GetFeed es público: el token de la URL es la credencial
+func (h *CalendarHandler) GetFeed(c *fiber.Ctx) error {
+	events, err := h.service.Feed(c.UserContext(), c.Params("token"))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, calendar.ContentType)
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return calendar.WriteICS(c, "ISO Stack - Auditorías", events)
+}
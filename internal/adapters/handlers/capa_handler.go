@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CAPAHandler struct {
+	service ports.CAPAService
+}
+
+func NewCAPAHandler(service ports.CAPAService) *CAPAHandler {
+	return &CAPAHandler{service: service}
+}
+
+func (h *CAPAHandler) CreateCAPA(c *fiber.Ctx) error {
+	var req struct {
+		ResponsibleUserID string    `json:"responsible_user_id"`
+		ResponsibleOrgID  string    `json:"responsible_org_id"`
+		DueDate           time.Time `json:"due_date"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	findingID := c.Params("finding_id")
+	userID := c.Locals("user_id").(string)
+
+	capa, err := h.service.CreateCAPA(c.UserContext(), auditID, findingID, userID, req.ResponsibleUserID, req.ResponsibleOrgID, req.DueDate)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(capa)
+}
+
+func (h *CAPAHandler) ListAuditCAPAs(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	capas, err := h.service.ListAuditCAPAs(c.UserContext(), auditID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(capas)
+}
+
+func (h *CAPAHandler) ListOrgCAPAs(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	capas, err := h.service.ListOrgCAPAs(c.UserContext(), orgID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(capas)
+}
+
+func (h *CAPAHandler) GetCAPA(c *fiber.Ctx) error {
+	capaID := c.Params("capa_id")
+	userID := c.Locals("user_id").(string)
+	orgID := c.Locals("org_id").(string)
+
+	capa, err := h.service.GetCAPA(c.UserContext(), capaID, userID, orgID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(capa)
+}
+
+func (h *CAPAHandler) ProposeCAPA(c *fiber.Ctx) error {
+	var req struct {
+		RootCause  string `json:"root_cause"`
+		ActionPlan string `json:"action_plan"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	capaID := c.Params("capa_id")
+	userID := c.Locals("user_id").(string)
+	orgID := c.Locals("org_id").(string)
+
+	capa, err := h.service.ProposeCAPA(c.UserContext(), capaID, userID, orgID, req.RootCause, req.ActionPlan)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(capa)
+}
+
+func (h *CAPAHandler) TransitionCAPA(c *fiber.Ctx) error {
+	var req struct {
+		Status string `json:"status"`
+		Notes  string `json:"notes"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	capaID := c.Params("capa_id")
+	userID := c.Locals("user_id").(string)
+	orgID := c.Locals("org_id").(string)
+
+	capa, err := h.service.TransitionCAPA(c.UserContext(), capaID, userID, orgID, domain.CAPAStatus(req.Status), req.Notes)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(capa)
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ChecklistTemplateHandler struct {
+	service ports.ChecklistTemplateService
+}
+
+func NewChecklistTemplateHandler(service ports.ChecklistTemplateService) *ChecklistTemplateHandler {
+	return &ChecklistTemplateHandler{service: service}
+}
+
+// templateRequest: cada ítem trae clause_id (pregunta del catálogo) o requirement (pregunta propia)
+type templateRequest struct {
+	Name        string                         `json:"name"`
+	Description string                         `json:"description"`
+	Items       []domain.ChecklistTemplateItem `json:"items"`
+}
+
+func (r templateRequest) toDetail() *domain.ChecklistTemplateDetail {
+	return &domain.ChecklistTemplateDetail{
+		ChecklistTemplate: domain.ChecklistTemplate{Name: r.Name, Description: r.Description},
+		Items:             r.Items,
+	}
+}
+
+func (h *ChecklistTemplateHandler) ListTemplates(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+
+	templates, err := h.service.ListTemplates(c.UserContext(), orgID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(templates)
+}
+
+func (h *ChecklistTemplateHandler) GetTemplate(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+
+	tpl, err := h.service.GetTemplate(c.UserContext(), orgID, c.Params("template_id"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(tpl)
+}
+
+func (h *ChecklistTemplateHandler) ListVersions(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+
+	versions, err := h.service.ListVersions(c.UserContext(), orgID, c.Params("template_id"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(versions)
+}
+
+func (h *ChecklistTemplateHandler) CreateTemplate(c *fiber.Ctx) error {
+	var req templateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	tpl, err := h.service.CreateTemplate(c.UserContext(), orgID, userID, req.toDetail())
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(tpl)
+}
+
+// UpdateTemplate publica una versión nueva a partir de la última
+func (h *ChecklistTemplateHandler) UpdateTemplate(c *fiber.Ctx) error {
+	var req templateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	tpl, err := h.service.UpdateTemplate(c.UserContext(), orgID, c.Params("template_id"), userID, req.toDetail())
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(tpl)
+}
+
+func (h *ChecklistTemplateHandler) CloneTemplate(c *fiber.Ctx) error {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	tpl, err := h.service.CloneTemplate(c.UserContext(), orgID, c.Params("template_id"), userID, req.Name)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(tpl)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ClientHandler struct {
+	service ports.ClientService
+}
+
+func NewClientHandler(service ports.ClientService) *ClientHandler {
+	return &ClientHandler{service: service}
+}
+
+type clientRequest struct {
+	Name         string   `json:"name"`
+	Sites        []string `json:"sites"`
+	ContactName  string   `json:"contact_name"`
+	ContactEmail string   `json:"contact_email"`
+	ContactPhone string   `json:"contact_phone"`
+}
+
+func (r clientRequest) toClient() *domain.Client {
+	return &domain.Client{
+		Name:         r.Name,
+		Sites:        joinList(r.Sites),
+		ContactName:  r.ContactName,
+		ContactEmail: r.ContactEmail,
+		ContactPhone: r.ContactPhone,
+	}
+}
+
+func (h *ClientHandler) CreateClient(c *fiber.Ctx) error {
+	var req clientRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	client, err := h.service.CreateClient(c.UserContext(), orgID, userID, req.Name, req.toClient())
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(client)
+}
+
+func (h *ClientHandler) ListClients(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	clients, err := h.service.ListClients(c.UserContext(), orgID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(clients)
+}
+
+func (h *ClientHandler) GetClient(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	client, err := h.service.GetClient(c.UserContext(), orgID, c.Params("client_id"), userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(client)
+}
+
+func (h *ClientHandler) UpdateClient(c *fiber.Ctx) error {
+	var req clientRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	client, err := h.service.UpdateClient(c.UserContext(), orgID, c.Params("client_id"), userID, req.toClient())
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(client)
+}
+
+// InviteClientUser acepta {"email": ...}; sin email invita al contacto del cliente
+func (h *ClientHandler) InviteClientUser(c *fiber.Ctx) error {
+	var req struct {
+		Email string `json:"email"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	inv, err := h.service.InviteClientUser(c.UserContext(), orgID, c.Params("client_id"), userID, req.Email)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(fiber.Map{"message": "invitation sent", "invitation": inv})
+}
+
+// --- Vista del auditado ---
+
+type AuditeeHandler struct {
+	service ports.AuditeeService
+}
+
+func NewAuditeeHandler(service ports.AuditeeService) *AuditeeHandler {
+	return &AuditeeHandler{service: service}
+}
+
+func (h *AuditeeHandler) ListAudits(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	audits, err := h.service.ListAudits(c.UserContext(), orgID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(audits)
+}
+
+func (h *AuditeeHandler) GetAudit(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	audit, err := h.service.GetAudit(c.UserContext(), orgID, c.Params("audit_id"), userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(audit)
+}
+
+func (h *AuditeeHandler) ListFindings(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	findings, err := h.service.ListFindings(c.UserContext(), orgID, c.Params("audit_id"), userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(findings)
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// errorStatus traduce los errores de negocio del dominio a códigos HTTP
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput):
+		return 400
+	case errors.Is(err, domain.ErrNotAssigned), errors.Is(err, domain.ErrForbidden):
+		return 403
+	case errors.Is(err, domain.ErrNotFound):
+		return 404
+	case errors.Is(err, domain.ErrAuditClosed), errors.Is(err, domain.ErrConflict),
+		errors.Is(err, domain.ErrDoubleBooked), errors.Is(err, gorm.ErrDuplicatedKey):
+		return 409
+	case errors.Is(err, domain.ErrTooLarge):
+		return 413
+	case errors.Is(err, domain.ErrUnsupported):
+		return 415
+	case errors.Is(err, context.DeadlineExceeded):
+		return 504
+	default:
+		return 500
+	}
+}
+
+func respondError(c *fiber.Ctx, err error) error {
+	return c.Status(errorStatus(err)).JSON(fiber.Map{"error": err.Error()})
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+type EvidenceHandler struct {
+	service ports.EvidenceService
+}
+
+func NewEvidenceHandler(service ports.EvidenceService) *EvidenceHandler {
+	return &EvidenceHandler{service: service}
+}
+
+// UploadEvidence espera multipart/form-data con el campo "file"
+// y opcionalmente "finding_id" y "checklist_item_id"
+func (h *EvidenceHandler) UploadEvidence(c *fiber.Ctx) error {
+	return h.upload(c, c.Params("audit_id"))
+}
+
+func (h *EvidenceHandler) upload(c *fiber.Ctx, auditID string) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "missing file"})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid file"})
+	}
+	defer file.Close()
+
+	userID := c.Locals("user_id").(string)
+
+	meta := &domain.Evidence{
+		FileName:        fileHeader.Filename,
+		Size:            fileHeader.Size,
+		FindingID:       c.FormValue("finding_id"),
+		ChecklistItemID: c.FormValue("checklist_item_id"),
+	}
+
+	evidence, err := h.service.UploadEvidence(c.UserContext(), auditID, userID, meta, file)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(evidence)
+}
+
+func (h *EvidenceHandler) ListEvidence(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	evidence, err := h.service.ListEvidence(c.UserContext(), auditID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(evidence)
+}
+
+func (h *EvidenceHandler) DownloadEvidence(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	evidenceID := c.Params("evidence_id")
+	userID := c.Locals("user_id").(string)
+
+	evidence, body, err := h.service.DownloadEvidence(c.UserContext(), auditID, evidenceID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, evidence.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", evidence.FileName))
+	c.Set("X-Content-SHA256", evidence.SHA256)
+	// fasthttp cierra el reader al terminar de enviar el cuerpo
+	return c.SendStream(body, int(evidence.Size))
+}
+
+func (h *EvidenceHandler) DeleteEvidence(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	evidenceID := c.Params("evidence_id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.service.DeleteEvidence(c.UserContext(), auditID, evidenceID, userID); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "evidence deleted"})
+}
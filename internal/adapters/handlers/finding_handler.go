@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+type FindingHandler struct {
+	service ports.FindingService
+}
+
+func NewFindingHandler(service ports.FindingService) *FindingHandler {
+	return &FindingHandler{service: service}
+}
+
+type findingRequest struct {
+	ChecklistItemID string `json:"checklist_item_id"`
+	Severity        string `json:"severity"`
+	Description     string `json:"description"`
+	EvidenceRef     string `json:"evidence_ref"`
+}
+
+func (r findingRequest) toDomain() *domain.Finding {
+	return &domain.Finding{
+		ChecklistItemID: r.ChecklistItemID,
+		Severity:        domain.FindingSeverity(r.Severity),
+		Description:     r.Description,
+		EvidenceRef:     r.EvidenceRef,
+	}
+}
+
+func (h *FindingHandler) CreateFinding(c *fiber.Ctx) error {
+	var req findingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	finding, err := h.service.CreateFinding(c.UserContext(), auditID, userID, req.toDomain())
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(finding)
+}
+
+func (h *FindingHandler) ListFindings(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	findings, err := h.service.ListFindings(c.UserContext(), auditID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(findings)
+}
+
+func (h *FindingHandler) GetFinding(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	findingID := c.Params("finding_id")
+	userID := c.Locals("user_id").(string)
+
+	finding, err := h.service.GetFinding(c.UserContext(), auditID, findingID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(finding)
+}
+
+func (h *FindingHandler) UpdateFinding(c *fiber.Ctx) error {
+	var req findingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	findingID := c.Params("finding_id")
+	userID := c.Locals("user_id").(string)
+
+	finding, err := h.service.UpdateFinding(c.UserContext(), auditID, findingID, userID, req.toDomain())
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(finding)
+}
+
+func (h *FindingHandler) DeleteFinding(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	findingID := c.Params("finding_id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.service.DeleteFinding(c.UserContext(), auditID, findingID, userID); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "finding deleted"})
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// GuestHandler expone a los participantes externos solo las operaciones habilitadas por el enlace
+type GuestHandler struct {
+	guestService    ports.GuestService
+	auditService    ports.AuditService
+	evidenceHandler *EvidenceHandler
+}
+
+func NewGuestHandler(guestService ports.GuestService, auditService ports.AuditService, evidenceHandler *EvidenceHandler) *GuestHandler {
+	return &GuestHandler{
+		guestService:    guestService,
+		auditService:    auditService,
+		evidenceHandler: evidenceHandler,
+	}
+}
+
+func (h *GuestHandler) OpenLink(c *fiber.Ctx) error {
+	view, err := h.guestService.OpenLink(c.UserContext(), c.Params("temp_link"))
+	if err != nil {
+		return c.Status(403).JSON(fiber.Map{"error": "invalid or expired link"})
+	}
+	return c.JSON(view)
+}
+
+func (h *GuestHandler) StartSession(c *fiber.Ctx) error {
+	token, view, err := h.guestService.StartGuestSession(c.UserContext(), c.Params("temp_link"))
+	if err != nil {
+		return c.Status(403).JSON(fiber.Map{"error": "invalid or expired link"})
+	}
+	return c.JSON(fiber.Map{"token": token, "audit": view})
+}
+
+func (h *GuestHandler) GetChecklist(c *fiber.Ctx) error {
+	auditID := c.Locals("audit_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	items, err := h.auditService.GetChecklist(c.UserContext(), auditID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(items)
+}
+
+func (h *GuestHandler) AnswerChecklistItem(c *fiber.Ctx) error {
+	var req struct {
+		Answer string `json:"answer"`
+		Notes  string `json:"notes"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Locals("audit_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	item, err := h.auditService.AnswerChecklistItem(c.UserContext(), auditID, c.Params("item_id"), userID, domain.ChecklistAnswer(req.Answer), req.Notes)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(item)
+}
+
+// UploadEvidence reutiliza el handler autenticado: GuestMiddleware ya dejó audit_id y user_id
+func (h *GuestHandler) UploadEvidence(c *fiber.Ctx) error {
+	return h.evidenceHandler.upload(c, c.Locals("audit_id").(string))
+}
+
+// GuestMiddleware valida tokens de invitado y que el enlace que los originó siga vigente
+func GuestMiddleware(secret string, repo ports.AuditRepository) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tokenString := strings.TrimPrefix(c.Get("Authorization"), "Bearer ")
+		if tokenString == "" {
+			return c.Status(401).JSON(fiber.Map{"error": "falta token de invitado"})
+		}
+
+		claims := jwt.MapClaims{}
+		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+			return []byte(secret), nil
+		})
+		if err != nil || !token.Valid || claims["guest"] != true {
+			return c.Status(401).JSON(fiber.Map{"error": "token de invitado inválido o expirado"})
+		}
+
+		linkID, _ := claims["jti"].(string)
+		link, err := repo.FindTemporaryLink(c.UserContext(), linkID)
+		if err != nil || link.RevokedAt != nil || time.Now().After(link.ExpiresAt) {
+			return c.Status(401).JSON(fiber.Map{"error": "el enlace fue revocado o venció"})
+		}
+
+		c.Locals("user_id", link.UserID)
+		c.Locals("audit_id", link.AuditID)
+		c.Locals("link", link)
+		withActor(c, link.UserID)
+		return c.Next()
+	}
+}
+
+// RequireScope corta con 403 si el enlace del invitado no incluye el scope
+func RequireScope(scope domain.LinkScope) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		link, ok := c.Locals("link").(*domain.TemporaryLink)
+		if !ok || !link.HasScope(scope) {
+			return c.Status(403).JSON(fiber.Map{"error": "el enlace no habilita: " + string(scope)})
+		}
+		return c.Next()
+	}
+}
@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
 	"strings"
+	"time"
 
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
 	"github.com/RiosHectorM/iso-stack/internal/core/ports"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -33,8 +37,17 @@ func AuthMiddleware(secret string, repo ports.AuthRepository) fiber.Handler {
 			return c.Status(401).JSON(fiber.Map{"error": "error al procesar claims"})
 		}
 
-		// 4. Verificar Revocación en BD
-		revoked, err := repo.IsTokenRevoked(tokenString)
+		// Los tokens de invitado solo sirven en /guest (ver GuestMiddleware)
+		if claims["guest"] == true {
+			return c.Status(401).JSON(fiber.Map{"error": "token de invitado no válido en esta ruta"})
+		}
+
+		// 4. Verificar Revocación en BD (por jti = ID de sesión)
+		jti, _ := claims["jti"].(string)
+		if jti == "" {
+			return c.Status(401).JSON(fiber.Map{"error": "token sin sesión asociada"})
+		}
+		revoked, err := repo.IsTokenRevoked(c.UserContext(), jti)
 		if err != nil {
 			return c.Status(500).JSON(fiber.Map{"error": "error interno verificando sesión"})
 		}
@@ -46,7 +59,61 @@ func AuthMiddleware(secret string, repo ports.AuthRepository) fiber.Handler {
 		c.Locals("user_id", claims["user_id"])
 		c.Locals("org_id", claims["org_id"])
 		c.Locals("role", claims["role"])
+		c.Locals("session_id", jti)
+
+		userID, _ := claims["user_id"].(string)
+		withActor(c, userID)
+
+		return c.Next()
+	}
+}
 
+// RequestContext deja en el contexto que reciben los servicios el ID de la petición
+// (middleware requestid) y la IP de origen; la bitácora de actividad los registra.
+// El contexto vence a los timeout: las consultas en curso se cancelan y la respuesta es 504.
+func RequestContext(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID, _ := c.Locals("requestid").(string)
+		ctx, cancel := context.WithTimeout(domain.WithRequestMeta(c.UserContext(), domain.RequestMeta{
+			RequestID: requestID,
+			IP:        c.IP(),
+		}), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
 		return c.Next()
 	}
 }
+
+// Deadline reemplaza el plazo general de la petición para rutas lentas (subidas, informes).
+// Conserva los datos de la petición pero no el vencimiento anterior.
+func Deadline(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(c.UserContext()), timeout)
+		defer cancel()
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
+
+// BodyLimit rechaza con 413 los cuerpos mayores a limit, salvo en las rutas que exime allow.
+// fasthttp lee el cuerpo antes de enrutar, así que el límite del servidor tiene que ser el
+// de las subidas; este middleware devuelve al resto de las rutas al límite general.
+func BodyLimit(limit int, allow func(c *fiber.Ctx) bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		size := c.Request().Header.ContentLength()
+		if size < 0 { // chunked: el cuerpo ya está leído
+			size = len(c.Request().Body())
+		}
+		if size > limit && (allow == nil || !allow(c)) {
+			return respondError(c, fmt.Errorf("%w: request body exceeds %d bytes", domain.ErrTooLarge, limit))
+		}
+		return c.Next()
+	}
+}
+
+// withActor agrega el usuario autenticado a los datos de la petición
+func withActor(c *fiber.Ctx, userID string) {
+	meta := domain.RequestMetaFrom(c.UserContext())
+	meta.ActorID = userID
+	c.SetUserContext(domain.WithRequestMeta(c.UserContext(), meta))
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestBodyLimit(t *testing.T) {
+	app := fiber.New(fiber.Config{BodyLimit: 1 << 20})
+	app.Use(BodyLimit(16, func(c *fiber.Ctx) bool { return strings.HasSuffix(c.Path(), "/upload") }))
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(204) }
+	app.Post("/upload", ok)
+	app.Post("/other", ok)
+
+	cases := []struct {
+		path string
+		size int
+		want int
+	}{
+		{"/other", 16, 204},
+		{"/other", 17, 413},
+		{"/upload", 1024, 204},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest("POST", tc.path, bytes.NewReader(make([]byte, tc.size)))
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tc.want {
+			t.Errorf("POST %s with %d bytes: status %d, want %d", tc.path, tc.size, resp.StatusCode, tc.want)
+		}
+	}
+}
@@ -24,18 +24,20 @@ func (h *OrganizationHandler) InviteStaff(c *fiber.Ctx) error {
 	}
 
 	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
 
-	if err := h.service.InviteStaff(req.Email, req.Role, orgID); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	inv, err := h.service.InviteStaff(c.UserContext(), req.Email, req.Role, orgID, userID)
+	if err != nil {
+		return respondError(c, err)
 	}
 
-	return c.Status(201).JSON(fiber.Map{"message": "invitation sent"})
+	return c.Status(201).JSON(fiber.Map{"message": "invitation sent", "invitation": inv})
 }
 
 func (h *OrganizationHandler) ListStaff(c *fiber.Ctx) error {
 	orgID := c.Locals("org_id").(string)
 
-	staff, err := h.service.ListStaff(orgID)
+	staff, err := h.service.ListStaff(c.UserContext(), orgID)
 	if err != nil {
 		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
 	}
@@ -54,10 +56,61 @@ func (h *OrganizationHandler) UpdateStaffStatus(c *fiber.Ctx) error {
 	}
 
 	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
 
-	if err := h.service.UpdateStaffStatus(req.UserID, orgID, req.Status); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	if err := h.service.UpdateStaffStatus(c.UserContext(), req.UserID, orgID, req.Status, userID); err != nil {
+		return respondError(c, err)
 	}
 
 	return c.JSON(fiber.Map{"message": "status updated"})
 }
+
+// --- Invitations ---
+
+func (h *OrganizationHandler) ListInvitations(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	invs, err := h.service.ListInvitations(c.UserContext(), orgID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(invs)
+}
+
+func (h *OrganizationHandler) ResendInvitation(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	inv, err := h.service.ResendInvitation(c.UserContext(), orgID, c.Params("invitation_id"), userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "invitation resent", "invitation": inv})
+}
+
+func (h *OrganizationHandler) RevokeInvitation(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	if err := h.service.RevokeInvitation(c.UserContext(), orgID, c.Params("invitation_id"), userID); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "invitation revoked"})
+}
+
+// AcceptInvitation es público: el token de la invitación autentica al invitado
+func (h *OrganizationHandler) AcceptInvitation(c *fiber.Ctx) error {
+	var req struct {
+		Token    string `json:"token"`
+		Password string `json:"password"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.Token == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	if err := h.service.AcceptInvitation(c.UserContext(), req.Token, req.Password); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "invitation accepted"})
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequirePermission corta con 403 si el rol del token no habilita la acción a nivel organización.
+// Debe ir después de AuthMiddleware. Los permisos por auditoría se evalúan en los servicios.
+func RequirePermission(action domain.Action) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("role").(string)
+		if !domain.OrgRoleAllows(domain.Role(role), action) {
+			return c.Status(403).JSON(fiber.Map{"error": "permiso denegado: " + string(action)})
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PlanningHandler struct {
+	service ports.PlanningService
+}
+
+func NewPlanningHandler(service ports.PlanningService) *PlanningHandler {
+	return &PlanningHandler{service: service}
+}
+
+type sessionRequest struct {
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Site      string    `json:"site"`
+	Process   string    `json:"process"`
+	Auditee   string    `json:"auditee"`
+	AuditorID string    `json:"auditor_id"`
+	Notes     string    `json:"notes"`
+}
+
+func (r sessionRequest) toSession() *domain.AuditSession {
+	return &domain.AuditSession{
+		StartsAt:  r.StartsAt,
+		EndsAt:    r.EndsAt,
+		Site:      r.Site,
+		Process:   r.Process,
+		Auditee:   r.Auditee,
+		AuditorID: r.AuditorID,
+		Notes:     r.Notes,
+	}
+}
+
+func (h *PlanningHandler) GetPlan(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	plan, err := h.service.GetPlan(c.UserContext(), auditID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(plan)
+}
+
+// SetSchedule espera {"planned_start": ..., "planned_end": ...}; ambos null borran la ventana
+func (h *PlanningHandler) SetSchedule(c *fiber.Ctx) error {
+	var req struct {
+		PlannedStart *time.Time `json:"planned_start"`
+		PlannedEnd   *time.Time `json:"planned_end"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	plan, err := h.service.SetSchedule(c.UserContext(), auditID, userID, req.PlannedStart, req.PlannedEnd)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(plan)
+}
+
+func (h *PlanningHandler) AddSession(c *fiber.Ctx) error {
+	var req sessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	session, err := h.service.AddSession(c.UserContext(), auditID, userID, req.toSession())
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(session)
+}
+
+func (h *PlanningHandler) UpdateSession(c *fiber.Ctx) error {
+	var req sessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	sessionID := c.Params("session_id")
+	userID := c.Locals("user_id").(string)
+
+	session, err := h.service.UpdateSession(c.UserContext(), auditID, sessionID, userID, req.toSession())
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(session)
+}
+
+func (h *PlanningHandler) DeleteSession(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	sessionID := c.Params("session_id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.service.DeleteSession(c.UserContext(), auditID, sessionID, userID); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "session deleted"})
+}
+
+func (h *PlanningHandler) ListConflicts(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	conflicts, err := h.service.ListConflicts(c.UserContext(), auditID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(conflicts)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProgrammeHandler struct {
+	service ports.ProgrammeService
+}
+
+func NewProgrammeHandler(service ports.ProgrammeService) *ProgrammeHandler {
+	return &ProgrammeHandler{service: service}
+}
+
+// programmeRequest recibe processes y clauses como listas JSON
+type programmeRequest struct {
+	Name       string   `json:"name"`
+	StartYear  int      `json:"start_year"`
+	EndYear    int      `json:"end_year"`
+	Objectives string   `json:"objectives"`
+	Processes  []string `json:"processes"`
+	Clauses    []string `json:"clauses"`
+}
+
+func (r programmeRequest) toProgramme() *domain.AuditProgramme {
+	return &domain.AuditProgramme{
+		Name:       r.Name,
+		StartYear:  r.StartYear,
+		EndYear:    r.EndYear,
+		Objectives: r.Objectives,
+		Processes:  joinList(r.Processes),
+		Clauses:    joinList(r.Clauses),
+	}
+}
+
+func (h *ProgrammeHandler) CreateProgramme(c *fiber.Ctx) error {
+	var req programmeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	programme, err := h.service.CreateProgramme(c.UserContext(), orgID, userID, req.toProgramme())
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(programme)
+}
+
+func (h *ProgrammeHandler) ListProgrammes(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	programmes, err := h.service.ListProgrammes(c.UserContext(), orgID, userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(programmes)
+}
+
+func (h *ProgrammeHandler) GetProgramme(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	programme, err := h.service.GetProgramme(c.UserContext(), orgID, c.Params("programme_id"), userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(programme)
+}
+
+func (h *ProgrammeHandler) UpdateProgramme(c *fiber.Ctx) error {
+	var req programmeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	programme, err := h.service.UpdateProgramme(c.UserContext(), orgID, c.Params("programme_id"), userID, req.toProgramme())
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(programme)
+}
+
+func (h *ProgrammeHandler) AddEntry(c *fiber.Ctx) error {
+	var req struct {
+		Period    string   `json:"period"`
+		Title     string   `json:"title"`
+		Processes []string `json:"processes"`
+		Clauses   []string `json:"clauses"`
+		ClientID  string   `json:"client_id"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	entry, err := h.service.AddEntry(c.UserContext(), orgID, c.Params("programme_id"), userID, &domain.ProgrammeEntry{
+		Period:    req.Period,
+		Title:     req.Title,
+		Processes: joinList(req.Processes),
+		Clauses:   joinList(req.Clauses),
+		ClientID:  req.ClientID,
+	})
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(entry)
+}
+
+func (h *ProgrammeHandler) ListEntries(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	entries, err := h.service.ListEntries(c.UserContext(), orgID, c.Params("programme_id"), userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(entries)
+}
+
+func (h *ProgrammeHandler) DeleteEntry(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	if err := h.service.DeleteEntry(c.UserContext(), orgID, c.Params("programme_id"), c.Params("entry_id"), userID); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "entry deleted"})
+}
+
+func (h *ProgrammeHandler) SpawnAudit(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	audit, err := h.service.SpawnAudit(c.UserContext(), orgID, c.Params("programme_id"), c.Params("entry_id"), userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(audit)
+}
+
+func (h *ProgrammeHandler) Coverage(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	coverage, err := h.service.Coverage(c.UserContext(), orgID, c.Params("programme_id"), userID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(coverage)
+}
+
+// joinList arma el formato separado por comas con el que se persisten procesos y cláusulas
+func joinList(values []string) string {
+	return strings.Join(values, ",")
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReportHandler struct {
+	service ports.ReportService
+}
+
+func NewReportHandler(service ports.ReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+// GetReport: GET /audits/:audit_id/report?format=pdf|docx|html (pdf por defecto)
+func (h *ReportHandler) GetReport(c *fiber.Ctx) error {
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+	format := c.Query("format", "pdf")
+
+	file, err := h.service.GenerateReport(c.UserContext(), auditID, userID, format)
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	// El HTML sale de una plantilla que escribe la organización: nunca se muestra en el
+	// origen de la API. Se descarga, y si el navegador lo abre igual, la CSP lo aísla sin scripts.
+	c.Set(fiber.HeaderContentType, file.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", file.FileName))
+	c.Set(fiber.HeaderContentSecurityPolicy, "sandbox")
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.Send(file.Content)
+}
+
+func (h *ReportHandler) SetConclusions(c *fiber.Ctx) error {
+	var req struct {
+		Conclusions string `json:"conclusions"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+
+	if err := h.service.SetConclusions(c.UserContext(), auditID, userID, req.Conclusions); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "conclusions updated"})
+}
+
+func (h *ReportHandler) GetTemplate(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+
+	tpl, err := h.service.GetTemplate(c.UserContext(), orgID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(tpl)
+}
+
+func (h *ReportHandler) SaveTemplate(c *fiber.Ctx) error {
+	var req domain.ReportTemplate
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	tpl, err := h.service.SaveTemplate(c.UserContext(), orgID, userID, &req)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(tpl)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+// reportStub devuelve siempre el mismo archivo; el resto de ReportService no se usa
+type reportStub struct {
+	ports.ReportService
+	file *domain.ReportFile
+}
+
+func (s reportStub) GenerateReport(ctx context.Context, auditID, userID, format string) (*domain.ReportFile, error) {
+	return s.file, nil
+}
+
+func TestGetReportIsSandboxedDownload(t *testing.T) {
+	h := NewReportHandler(reportStub{file: &domain.ReportFile{
+		FileName:    "informe.html",
+		ContentType: "text/html; charset=utf-8",
+		Content:     []byte(`<script>alert(1)</script>`),
+	}})
+	app := fiber.New()
+	app.Get("/audits/:audit_id/report", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-1")
+		return c.Next()
+	}, h.GetReport)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/audits/a1/report?format=html", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("status %d, want 200", resp.StatusCode)
+	}
+	if got := resp.Header.Get(fiber.HeaderContentDisposition); !strings.HasPrefix(got, "attachment") || !strings.Contains(got, `filename="informe.html"`) {
+		t.Errorf("Content-Disposition %q, want an attachment named informe.html", got)
+	}
+	if got := resp.Header.Get(fiber.HeaderContentSecurityPolicy); got != "sandbox" {
+		t.Errorf("Content-Security-Policy %q, want sandbox", got)
+	}
+	if got := resp.Header.Get(fiber.HeaderXContentTypeOptions); got != "nosniff" {
+		t.Errorf("X-Content-Type-Options %q, want nosniff", got)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != `<script>alert(1)</script>` {
+		t.Errorf("body %q", body)
+	}
+}
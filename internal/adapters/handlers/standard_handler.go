@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/catalog"
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/gofiber/fiber/v2"
+)
+
+type StandardHandler struct {
+	service ports.StandardService
+}
+
+func NewStandardHandler(service ports.StandardService) *StandardHandler {
+	return &StandardHandler{service: service}
+}
+
+func (h *StandardHandler) ListStandards(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+
+	standards, err := h.service.ListStandards(c.UserContext(), orgID)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(standards)
+}
+
+func (h *StandardHandler) GetStandard(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+
+	standard, err := h.service.GetStandard(c.UserContext(), orgID, c.Params("standard_id"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(standard)
+}
+
+// ImportStandard crea una norma privada. Acepta el JSON del catálogo en el cuerpo, o
+// multipart/form-data con "file" (.json o .csv); para CSV, code/version/title van como campos
+func (h *StandardHandler) ImportStandard(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	var (
+		standard *domain.Standard
+		clauses  []domain.Clause
+		err      error
+	)
+	if fileHeader, ferr := c.FormFile("file"); ferr == nil {
+		file, oerr := fileHeader.Open()
+		if oerr != nil {
+			return c.Status(400).JSON(fiber.Map{"error": "invalid file"})
+		}
+		defer file.Close()
+
+		format := strings.ToLower(c.FormValue("format"))
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+		}
+		switch format {
+		case "csv":
+			standard, clauses, err = catalog.ParseCSV(file, &domain.Standard{
+				Code:    c.FormValue("code"),
+				Version: c.FormValue("version"),
+				Title:   c.FormValue("title"),
+			})
+		case "json":
+			standard, clauses, err = catalog.ParseJSON(file)
+		default:
+			return c.Status(400).JSON(fiber.Map{"error": "format must be json or csv"})
+		}
+	} else {
+		standard, clauses, err = catalog.ParseJSON(bytes.NewReader(c.Body()))
+	}
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	detail, err := h.service.ImportStandard(c.UserContext(), orgID, userID, standard, clauses)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(detail)
+}
+
+func (h *StandardHandler) DeleteStandard(c *fiber.Ctx) error {
+	orgID := c.Locals("org_id").(string)
+	userID := c.Locals("user_id").(string)
+
+	if err := h.service.DeleteStandard(c.UserContext(), orgID, c.Params("standard_id"), userID); err != nil {
+		return respondError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "standard deleted"})
+}
+
+// BuildChecklist espera {"standard_id": ..., "clauses": ["7.1.5", ...]}; sin clauses usa la norma completa
+func (h *StandardHandler) BuildChecklist(c *fiber.Ctx) error {
+	var req struct {
+		StandardID string   `json:"standard_id"`
+		Clauses    []string `json:"clauses"`
+	}
+	if err := c.BodyParser(&req); err != nil || req.StandardID == "" {
+		return c.Status(400).JSON(fiber.Map{"error": "invalid request"})
+	}
+
+	auditID := c.Params("audit_id")
+	userID := c.Locals("user_id").(string)
+	orgID := c.Locals("org_id").(string)
+
+	items, err := h.service.BuildChecklist(c.UserContext(), auditID, userID, orgID, req.StandardID, req.Clauses)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return c.Status(201).JSON(items)
+}
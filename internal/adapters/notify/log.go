@@ -0,0 +1,24 @@
+package notify
+
+import (
+	"log"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+// LogNotifier escribe las notificaciones en el log en lugar de enviarlas (desarrollo)
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(n domain.Notification) error {
+	subject, body, err := Render(n)
+	if err != nil {
+		return err
+	}
+	log.Printf("[notify] to=%s subject=%q\n%s", n.To, subject, body)
+	return nil
+}
+
+// NoopNotifier descarta todas las notificaciones
+type NoopNotifier struct{}
+
+func (NoopNotifier) Notify(domain.Notification) error { return nil }
@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+// SMTPNotifier envía correos por SMTP. Sin usuario no autentica,
+// lo que permite usar un sink local (Mailpit del docker-compose) en desarrollo.
+type SMTPNotifier struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	From     string
+}
+
+func (s *SMTPNotifier) Notify(n domain.Notification) error {
+	subject, body, err := Render(n)
+	if err != nil {
+		return err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", n.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(body)
+
+	var auth smtp.Auth
+	if s.User != "" {
+		auth = smtp.PlainAuth("", s.User, s.Password, s.Host)
+	}
+	return smtp.SendMail(net.JoinHostPort(s.Host, s.Port), auth, s.From, []string{n.To}, msg.Bytes())
+}
@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"io"
+	"mime"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+// smtpMessage es lo que recibió el servidor de prueba en una transacción
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// stubSMTP atiende una única conexión con el mínimo de SMTP que usa net/smtp sin TLS ni AUTH
+func stubSMTP(t *testing.T) (host, port string, received <-chan smtpMessage) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	ch := make(chan smtpMessage, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tp := textproto.NewConn(conn)
+		var msg smtpMessage
+		tp.PrintfLine("220 stub ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch verb {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250 stub")
+			case "MAIL":
+				msg.from = strings.TrimSuffix(strings.TrimPrefix(line[len("MAIL FROM:"):], "<"), ">")
+				tp.PrintfLine("250 OK")
+			case "RCPT":
+				msg.to = append(msg.to, strings.TrimSuffix(strings.TrimPrefix(line[len("RCPT TO:"):], "<"), ">"))
+				tp.PrintfLine("250 OK")
+			case "DATA":
+				tp.PrintfLine("354 go ahead")
+				data, err := io.ReadAll(tp.DotReader())
+				if err != nil {
+					return
+				}
+				msg.data = string(data)
+				tp.PrintfLine("250 OK")
+			case "QUIT":
+				tp.PrintfLine("221 bye")
+				ch <- msg
+				return
+			default:
+				tp.PrintfLine("502 not implemented")
+			}
+		}
+	}()
+
+	host, port, _ = net.SplitHostPort(ln.Addr().String())
+	return host, port, ch
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	host, port, received := stubSMTP(t)
+	notifier := &SMTPNotifier{Host: host, Port: port, From: "noreply@iso-stack.test"}
+
+	err := notifier.Notify(domain.Notification{
+		To:     "auditor@example.com",
+		Locale: "es",
+		Kind:   domain.NotifyAuditStatus,
+		Data: map[string]string{
+			"audit_title": "Auditoría de gestión",
+			"from_status": "Planificada",
+			"status":      "En_Curso",
+			"reason":      "Reunión de apertura",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	msg := <-received
+	if msg.from != "noreply@iso-stack.test" || len(msg.to) != 1 || msg.to[0] != "auditor@example.com" {
+		t.Fatalf("envelope: from=%q to=%v", msg.from, msg.to)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(msg.data))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	for header, want := range map[string]string{
+		"From":                      "noreply@iso-stack.test",
+		"To":                        "auditor@example.com",
+		"Mime-Version":              "1.0",
+		"Content-Type":              "text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding": "8bit",
+	} {
+		if got := parsed.Header.Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+	if _, err := parsed.Header.Date(); err != nil {
+		t.Errorf("Date: %v", err)
+	}
+
+	// El asunto lleva acentos: tiene que viajar codificado (RFC 2047) y decodificar igual
+	rawSubject := parsed.Header.Get("Subject")
+	if !strings.HasPrefix(rawSubject, "=?utf-8?q?") {
+		t.Errorf("Subject is not Q-encoded: %q", rawSubject)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(rawSubject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `La auditoría "Auditoría de gestión" pasó a En_Curso`; subject != want {
+		t.Errorf("Subject = %q, want %q", subject, want)
+	}
+
+	body, err := io.ReadAll(parsed.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Planificada -> En_Curso", "Motivo: Reunión de apertura"} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("body does not contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestSMTPNotifierUnknownKind(t *testing.T) {
+	// Sin plantilla no se llega a abrir la conexión
+	notifier := &SMTPNotifier{Host: "127.0.0.1", Port: "1", From: "noreply@iso-stack.test"}
+	err := notifier.Notify(domain.Notification{To: "a@example.com", Kind: "unknown"})
+	if err == nil || !strings.Contains(err.Error(), "no template") {
+		t.Fatalf("got %v, want a missing template error", err)
+	}
+}
+
+func TestRenderFallsBackToSpanish(t *testing.T) {
+	data := map[string]string{"audit_title": "X", "from_status": "A", "status": "B"}
+	es, _, err := Render(domain.Notification{Locale: "es", Kind: domain.NotifyAuditStatus, Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _, err := Render(domain.Notification{Locale: "pt", Kind: domain.NotifyAuditStatus, Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other != es {
+		t.Fatalf("unknown locale rendered %q, want the Spanish subject %q", other, es)
+	}
+}
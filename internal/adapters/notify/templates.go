@@ -0,0 +1,123 @@
+// Package notify contiene los adaptadores de ports.Notifier y sus plantillas localizadas.
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+const defaultLocale = "es"
+
+type messageTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func mustTemplate(subject, body string) messageTemplate {
+	return messageTemplate{
+		subject: template.Must(template.New("subject").Parse(subject)),
+		body:    template.Must(template.New("body").Parse(body)),
+	}
+}
+
+// templates: locale -> tipo de notificación -> plantilla
+var templates = map[string]map[domain.NotificationKind]messageTemplate{
+	"es": {
+		domain.NotifyInvitation: mustTemplate(
+			"Invitación a {{.org_name}} en ISO Stack",
+			`Hola,
+
+Fuiste invitado a unirte a {{.org_name}} con el rol {{.role}}.
+
+Para aceptar la invitación ingresá al siguiente enlace (vence el {{.expires_at}}):
+{{.accept_url}}
+
+Si no esperabas esta invitación, podés ignorar este correo.
+`),
+		domain.NotifyAuditAssignment: mustTemplate(
+			"Asignación a la auditoría \"{{.audit_title}}\"",
+			`Hola,
+
+Fuiste asignado a la auditoría "{{.audit_title}}" con el rol {{.role}}.
+Ingresá a ISO Stack para aceptar o rechazar la asignación.
+{{if .temp_link_url}}
+Acceso directo para participantes externos:
+{{.temp_link_url}}
+{{end}}`),
+		domain.NotifyAuditStatus: mustTemplate(
+			"La auditoría \"{{.audit_title}}\" pasó a {{.status}}",
+			`Hola,
+
+La auditoría "{{.audit_title}}" cambió de estado: {{.from_status}} -> {{.status}}.
+{{if .reason}}Motivo: {{.reason}}
+{{end}}`),
+		domain.NotifyAssignmentReply: mustTemplate(
+			"{{.user}} respondió a la asignación en \"{{.audit_title}}\"",
+			`Hola,
+
+{{.user}} ({{.role}}) {{if eq .response "Aceptado"}}aceptó{{else}}rechazó{{end}} la asignación a la auditoría "{{.audit_title}}".
+{{if .reason}}Motivo: {{.reason}}
+{{end}}`),
+	},
+	"en": {
+		domain.NotifyInvitation: mustTemplate(
+			"Invitation to {{.org_name}} on ISO Stack",
+			`Hello,
+
+You have been invited to join {{.org_name}} as {{.role}}.
+
+To accept the invitation open the following link (expires on {{.expires_at}}):
+{{.accept_url}}
+
+If you were not expecting this invitation, you can ignore this email.
+`),
+		domain.NotifyAuditAssignment: mustTemplate(
+			"Assigned to audit \"{{.audit_title}}\"",
+			`Hello,
+
+You have been assigned to the audit "{{.audit_title}}" as {{.role}}.
+Sign in to ISO Stack to accept or decline the assignment.
+{{if .temp_link_url}}
+Direct access for external participants:
+{{.temp_link_url}}
+{{end}}`),
+		domain.NotifyAuditStatus: mustTemplate(
+			"Audit \"{{.audit_title}}\" is now {{.status}}",
+			`Hello,
+
+The audit "{{.audit_title}}" changed status: {{.from_status}} -> {{.status}}.
+{{if .reason}}Reason: {{.reason}}
+{{end}}`),
+		domain.NotifyAssignmentReply: mustTemplate(
+			"{{.user}} replied to the assignment on \"{{.audit_title}}\"",
+			`Hello,
+
+{{.user}} ({{.role}}) {{if eq .response "Aceptado"}}accepted{{else}}declined{{end}} the assignment to the audit "{{.audit_title}}".
+{{if .reason}}Reason: {{.reason}}
+{{end}}`),
+	},
+}
+
+// Render resuelve asunto y cuerpo de la notificación, con español como idioma de respaldo
+func Render(n domain.Notification) (subject, body string, err error) {
+	byKind, ok := templates[n.Locale]
+	if !ok {
+		byKind = templates[defaultLocale]
+	}
+	tpl, ok := byKind[n.Kind]
+	if !ok {
+		return "", "", fmt.Errorf("no template for notification %q", n.Kind)
+	}
+
+	var sb, bb bytes.Buffer
+	if err := tpl.subject.Execute(&sb, n.Data); err != nil {
+		return "", "", err
+	}
+	if err := tpl.body.Execute(&bb, n.Data); err != nil {
+		return "", "", err
+	}
+	return sb.String(), bb.String(), nil
+}
@@ -0,0 +1,164 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+const (
+	docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+</Types>`
+	docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+</Relationships>`
+)
+
+// DOCXRenderer arma un documento WordprocessingML mínimo (OOXML) sin dependencias externas
+type DOCXRenderer struct{}
+
+func (DOCXRenderer) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+}
+
+func (DOCXRenderer) Render(w io.Writer, report *domain.AuditReport) error {
+	var body docxBody
+
+	body.paragraph(report.Template.Header, docxSmall)
+	body.paragraph(report.Template.Title, docxTitle)
+	body.paragraph(report.Template.Intro, docxNormal)
+
+	body.paragraph("Datos de la auditoría", docxHeading)
+	body.table([][]string{
+		{"Título", report.Audit.Title},
+		{"Estado", string(report.Audit.Status)},
+		{"Creada", report.Audit.CreatedAt.Format("02/01/2006")},
+		{"Informe generado", report.GeneratedAt.Format("02/01/2006 15:04")},
+	})
+
+	body.paragraph("Equipo auditor", docxHeading)
+	team := [][]string{{"Integrante", "Rol", "Aceptación"}}
+	for _, m := range report.Team {
+		team = append(team, []string{m.Email, string(m.RoleInAudit), string(m.AcceptanceStatus)})
+	}
+	body.table(team)
+
+	body.paragraph("Resultados de la checklist", docxHeading)
+	var summary []string
+	for _, answer := range summaryOrder {
+		summary = append(summary, fmt.Sprintf("%s: %d", answer, report.Summary[answer]))
+	}
+	body.paragraph(strings.Join(summary, "   "), docxNormal)
+	checklist := [][]string{{"Norma", "Cláusula", "Requisito", "Resultado", "Notas"}}
+	for _, item := range report.Checklist {
+		checklist = append(checklist, []string{item.Standard, item.ClauseNumber, item.Requirement, string(item.Answer), item.Notes})
+	}
+	body.table(checklist)
+
+	body.paragraph("Hallazgos", docxHeading)
+	findings := [][]string{{"Severidad", "Descripción", "Evidencia"}}
+	for _, f := range report.Findings {
+		findings = append(findings, []string{string(f.Severity), f.Description, f.EvidenceRef})
+	}
+	body.table(findings)
+
+	body.paragraph("Conclusiones", docxHeading)
+	body.paragraph(report.Audit.Conclusions, docxNormal)
+	body.paragraph(report.Template.Footer, docxSmall)
+
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxRels},
+		{"word/document.xml", body.document()},
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+type docxStyle int
+
+const (
+	docxNormal docxStyle = iota
+	docxSmall
+	docxHeading
+	docxTitle
+)
+
+type docxBody struct {
+	buf bytes.Buffer
+}
+
+func (b *docxBody) paragraph(text string, style docxStyle) {
+	if text == "" {
+		return
+	}
+	props := ""
+	switch style {
+	case docxSmall:
+		props = `<w:rPr><w:i/><w:sz w:val="16"/></w:rPr>`
+	case docxHeading:
+		props = `<w:rPr><w:b/><w:sz w:val="28"/></w:rPr>`
+	case docxTitle:
+		props = `<w:rPr><w:b/><w:sz w:val="36"/></w:rPr>`
+	}
+	b.buf.WriteString(`<w:p><w:r>` + props)
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			b.buf.WriteString(`<w:br/>`)
+		}
+		b.buf.WriteString(`<w:t xml:space="preserve">` + escape(line) + `</w:t>`)
+	}
+	b.buf.WriteString(`</w:r></w:p>`)
+}
+
+// table escribe una tabla con bordes; la primera fila se usa como encabezado
+func (b *docxBody) table(rows [][]string) {
+	b.buf.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="5000" w:type="pct"/><w:tblBorders>` +
+		`<w:top w:val="single" w:sz="4"/><w:left w:val="single" w:sz="4"/><w:bottom w:val="single" w:sz="4"/>` +
+		`<w:right w:val="single" w:sz="4"/><w:insideH w:val="single" w:sz="4"/><w:insideV w:val="single" w:sz="4"/>` +
+		`</w:tblBorders></w:tblPr>`)
+	for i, row := range rows {
+		b.buf.WriteString(`<w:tr>`)
+		for _, cell := range row {
+			props := ""
+			if i == 0 {
+				props = `<w:rPr><w:b/></w:rPr>`
+			}
+			b.buf.WriteString(`<w:tc><w:p><w:r>` + props + `<w:t xml:space="preserve">` + escape(cell) + `</w:t></w:r></w:p></w:tc>`)
+		}
+		b.buf.WriteString(`</w:tr>`)
+	}
+	b.buf.WriteString(`</w:tbl><w:p/>`)
+}
+
+func (b *docxBody) document() string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+		b.buf.String() +
+		`</w:body></w:document>`
+}
+
+func escape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,81 @@
+package report
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+const defaultHTMLTemplate = `<!DOCTYPE html>
+<html lang="es">
+<head>
+<meta charset="utf-8">
+<title>{{.Template.Title}} - {{.Audit.Title}}</title>
+<style>
+ body { font-family: Arial, sans-serif; margin: 2em; color: #222; }
+ table { border-collapse: collapse; width: 100%; margin-bottom: 1.5em; }
+ th, td { border: 1px solid #999; padding: 4px 8px; text-align: left; vertical-align: top; }
+ th { background: #eee; }
+ header, footer { color: #555; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<header>{{.Template.Header}}</header>
+<h1>{{.Template.Title}}</h1>
+<p>{{.Template.Intro}}</p>
+
+<h2>Datos de la auditoría</h2>
+<table>
+<tr><th>Título</th><td>{{.Audit.Title}}</td></tr>
+<tr><th>Estado</th><td>{{.Audit.Status}}</td></tr>
+<tr><th>Creada</th><td>{{.Audit.CreatedAt.Format "02/01/2006"}}</td></tr>
+<tr><th>Informe generado</th><td>{{.GeneratedAt.Format "02/01/2006 15:04"}}</td></tr>
+</table>
+
+<h2>Equipo auditor</h2>
+<table>
+<tr><th>Integrante</th><th>Rol</th><th>Aceptación</th></tr>
+{{range .Team}}<tr><td>{{.Email}}</td><td>{{.RoleInAudit}}</td><td>{{.AcceptanceStatus}}</td></tr>
+{{end}}</table>
+
+<h2>Resultados de la checklist</h2>
+<p>{{range $answer, $count := .Summary}}{{$answer}}: {{$count}} &nbsp; {{end}}</p>
+<table>
+<tr><th>Norma</th><th>Cláusula</th><th>Requisito</th><th>Resultado</th><th>Notas</th></tr>
+{{range .Checklist}}<tr><td>{{.Standard}}</td><td>{{.ClauseNumber}}</td><td>{{.Requirement}}</td><td>{{.Answer}}</td><td>{{.Notes}}</td></tr>
+{{end}}</table>
+
+<h2>Hallazgos</h2>
+<table>
+<tr><th>Severidad</th><th>Descripción</th><th>Evidencia</th></tr>
+{{range .Findings}}<tr><td>{{.Severity}}</td><td>{{.Description}}</td><td>{{.EvidenceRef}}</td></tr>
+{{else}}<tr><td colspan="3">Sin hallazgos registrados</td></tr>
+{{end}}</table>
+
+<h2>Conclusiones</h2>
+<p>{{.Audit.Conclusions}}</p>
+
+<footer>{{.Template.Footer}}</footer>
+</body>
+</html>
+`
+
+var defaultHTML = template.Must(template.New("report").Parse(defaultHTMLTemplate))
+
+// HTMLRenderer usa el html/template de la organización si lo definió, o el predeterminado
+type HTMLRenderer struct{}
+
+func (HTMLRenderer) ContentType() string { return "text/html; charset=utf-8" }
+
+func (HTMLRenderer) Render(w io.Writer, report *domain.AuditReport) error {
+	tpl := defaultHTML
+	if report.Template.HTMLTemplate != "" {
+		custom, err := template.New("custom").Parse(report.Template.HTMLTemplate)
+		if err != nil {
+			return err
+		}
+		tpl = custom
+	}
+	return tpl.Execute(w, report)
+}
@@ -0,0 +1,108 @@
+package report
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/go-pdf/fpdf"
+)
+
+// PDFRenderer genera el informe en PDF sin servicios externos
+type PDFRenderer struct{}
+
+func (PDFRenderer) ContentType() string { return "application/pdf" }
+
+func (PDFRenderer) Render(w io.Writer, report *domain.AuditReport) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	// Las fuentes core son cp1252: traducir UTF-8 para acentos y eñes
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 6, tr(report.Template.Header), "", 1, "R", false, 0, "")
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 6, tr(fmt.Sprintf("%s - Página %d", report.Template.Footer, pdf.PageNo())), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	heading := func(text string) {
+		pdf.Ln(4)
+		pdf.SetFont("Helvetica", "B", 13)
+		pdf.CellFormat(0, 8, tr(text), "", 1, "", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+	}
+	row := func(widths []float64, cols []string, bold bool) {
+		style := ""
+		if bold {
+			style = "B"
+		}
+		pdf.SetFont("Helvetica", style, 9)
+		for i, col := range cols {
+			pdf.CellFormat(widths[i], 6, tr(truncate(col, int(widths[i]/1.8))), "1", 0, "", bold, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.MultiCell(0, 9, tr(report.Template.Title), "", "", false)
+	pdf.SetFont("Helvetica", "", 10)
+	if report.Template.Intro != "" {
+		pdf.MultiCell(0, 5, tr(report.Template.Intro), "", "", false)
+	}
+
+	heading("Datos de la auditoría")
+	pdf.MultiCell(0, 5, tr(fmt.Sprintf("Título: %s\nEstado: %s\nCreada: %s\nInforme generado: %s",
+		report.Audit.Title, report.Audit.Status,
+		report.Audit.CreatedAt.Format("02/01/2006"), report.GeneratedAt.Format("02/01/2006 15:04"))), "", "", false)
+
+	heading("Equipo auditor")
+	teamWidths := []float64{90, 50, 40}
+	row(teamWidths, []string{"Integrante", "Rol", "Aceptación"}, true)
+	for _, m := range report.Team {
+		row(teamWidths, []string{m.Email, string(m.RoleInAudit), string(m.AcceptanceStatus)}, false)
+	}
+
+	heading("Resultados de la checklist")
+	for _, answer := range summaryOrder {
+		pdf.CellFormat(45, 6, tr(fmt.Sprintf("%s: %d", answer, report.Summary[answer])), "", 0, "", false, 0, "")
+	}
+	pdf.Ln(8)
+	checkWidths := []float64{30, 18, 92, 25, 25}
+	row(checkWidths, []string{"Norma", "Cláusula", "Requisito", "Resultado", "Notas"}, true)
+	for _, item := range report.Checklist {
+		row(checkWidths, []string{item.Standard, item.ClauseNumber, item.Requirement, string(item.Answer), item.Notes}, false)
+	}
+
+	heading("Hallazgos")
+	if len(report.Findings) == 0 {
+		pdf.CellFormat(0, 6, tr("Sin hallazgos registrados"), "", 1, "", false, 0, "")
+	}
+	for _, f := range report.Findings {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(0, 6, tr(string(f.Severity)), "", 1, "", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.MultiCell(0, 5, tr(f.Description), "", "", false)
+		if f.EvidenceRef != "" {
+			pdf.MultiCell(0, 5, tr("Evidencia: "+f.EvidenceRef), "", "", false)
+		}
+		pdf.Ln(2)
+	}
+
+	heading("Conclusiones")
+	pdf.MultiCell(0, 5, tr(report.Audit.Conclusions), "", "", false)
+
+	return pdf.Output(w)
+}
+
+// truncate recorta textos largos para que entren en una celda de tabla
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if max < 4 || len(r) <= max {
+		return s
+	}
+	return string(r[:max-3]) + "..."
+}
@@ -0,0 +1,26 @@
+// Package report contiene los renderizadores del informe de auditoría (PDF, DOCX y HTML),
+// generados íntegramente en Go sin servicios externos.
+package report
+
+import (
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// summaryOrder fija el orden del resumen de resultados en los formatos que no usan templates
+var summaryOrder = []domain.ChecklistAnswer{
+	domain.AnswerConforme,
+	domain.AnswerNoConforme,
+	domain.AnswerObservacion,
+	domain.AnswerNoAplica,
+	domain.AnswerPendiente,
+}
+
+// Renderers devuelve los renderizadores disponibles indexados por formato (?format=)
+func Renderers() map[string]ports.ReportRenderer {
+	return map[string]ports.ReportRenderer{
+		"pdf":  PDFRenderer{},
+		"docx": DOCXRenderer{},
+		"html": HTMLRenderer{},
+	}
+}
@@ -0,0 +1,751 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+// MemoryRepository implementa AuthRepository, OrganizationRepository, AuditRepository y
+// ReportTemplateRepository en memoria, con la misma semántica que PostgresRepository (ver
+// repotest): valores por defecto de las columnas, unicidad, soft-delete de usuarios y los
+// mismos errores de GORM para "no encontrado".
+// Pensado para pruebas de servicios y entornos sin base de datos; no persiste nada.
+type MemoryRepository struct {
+	mu sync.RWMutex
+
+	orgs        map[string]domain.Organization
+	users       map[string]domain.User
+	members     map[memberKey]domain.UserOrganization
+	revoked     []domain.RevokedToken
+	sessions    map[string]domain.Session
+	audits      map[string]domain.Audit
+	assignments map[memberKey]domain.AuditAssignment // Clave: AuditID / UserID
+	checklist   map[string]domain.ChecklistItem
+	links       map[string]domain.TemporaryLink
+	history     []domain.AuditStatusChange
+	templates   map[string]domain.ReportTemplate // Clave: OrganizationID
+}
+
+// memberKey es la clave primaria compuesta de las tablas de vínculo
+type memberKey struct {
+	parent string
+	userID string
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		orgs:        map[string]domain.Organization{},
+		users:       map[string]domain.User{},
+		members:     map[memberKey]domain.UserOrganization{},
+		sessions:    map[string]domain.Session{},
+		audits:      map[string]domain.Audit{},
+		assignments: map[memberKey]domain.AuditAssignment{},
+		checklist:   map[string]domain.ChecklistItem{},
+		links:       map[string]domain.TemporaryLink{},
+		templates:   map[string]domain.ReportTemplate{},
+	}
+}
+
+func duplicated(what string) error {
+	return fmt.Errorf("%w: %s", gorm.ErrDuplicatedKey, what)
+}
+
+// --- AuthRepository Implementation ---
+
+func (r *MemoryRepository) CreateUserWithOrg(ctx context.Context, user *domain.User, org *domain.Organization, userOrg *domain.UserOrganization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Validar todo antes de escribir: la alta es atómica como la transacción de Postgres
+	if err := r.checkNewUser(user); err != nil {
+		return err
+	}
+	_ = org.BeforeCreate(nil)
+	if _, ok := r.orgs[org.ID]; ok {
+		return duplicated("organization id")
+	}
+	_ = user.BeforeCreate(nil)
+
+	now := time.Now()
+	if org.CreatedAt.IsZero() {
+		org.CreatedAt = now
+	}
+	r.orgs[org.ID] = *org
+	r.insertUser(user, now)
+
+	userOrg.UserID = user.ID
+	userOrg.OrganizationID = org.ID
+	return r.insertMember(userOrg)
+}
+
+func (r *MemoryRepository) FindUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email && !user.DeletedAt.Valid {
+			return &user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MemoryRepository) FindUserByID(ctx context.Context, userID string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[userID]
+	if !ok || user.DeletedAt.Valid {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &user, nil
+}
+
+// DeleteUser da de baja lógica al usuario (DeletedAt), como gorm.Delete sobre un modelo con soft-delete.
+// El email sigue reservado: el índice único incluye a los usuarios borrados.
+func (r *MemoryRepository) DeleteUser(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user, ok := r.users[userID]; ok && !user.DeletedAt.Valid {
+		user.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+		r.users[userID] = user
+	}
+	return nil
+}
+
+func (r *MemoryRepository) GetUserPrimaryOrg(ctx context.Context, userID string) (*domain.UserOrganization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var primary *domain.UserOrganization
+	for _, member := range r.members {
+		if member.UserID != userID || member.Status != domain.MemberActivo {
+			continue
+		}
+		if primary == nil || member.JoinedAt.Before(primary.JoinedAt) {
+			m := member
+			primary = &m
+		}
+	}
+	if primary == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return primary, nil
+}
+
+func (r *MemoryRepository) ListUserOrganizations(ctx context.Context, userID string) ([]domain.OrganizationMembership, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var memberships []domain.OrganizationMembership
+	for _, member := range r.members {
+		org, ok := r.orgs[member.OrganizationID] // JOIN: sin organización no hay fila
+		if member.UserID != userID || !ok {
+			continue
+		}
+		memberships = append(memberships, domain.OrganizationMembership{
+			OrganizationID: member.OrganizationID,
+			Name:           org.Name,
+			RoleDefault:    member.RoleDefault,
+			Status:         member.Status,
+			JoinedAt:       member.JoinedAt,
+		})
+	}
+	sort.SliceStable(memberships, func(i, j int) bool { return memberships[i].JoinedAt.Before(memberships[j].JoinedAt) })
+	return memberships, nil
+}
+
+func (r *MemoryRepository) FindMembership(ctx context.Context, userID, orgID string) (*domain.UserOrganization, error) {
+	return r.FindUserOrg(ctx, userID, orgID)
+}
+
+func (r *MemoryRepository) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.revoked = append(r.revoked, domain.RevokedToken{
+		ID:        uint(len(r.revoked) + 1),
+		JTI:       jti,
+		ExpiresAt: expiresAt,
+	})
+	return nil
+}
+
+func (r *MemoryRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, revoked := range r.revoked {
+		if revoked.JTI == jti {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *MemoryRepository) CreateSession(ctx context.Context, session *domain.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sessions[session.ID]; ok || session.ID == "" {
+		return duplicated("session id")
+	}
+	for _, s := range r.sessions {
+		if s.RefreshTokenHash == session.RefreshTokenHash {
+			return duplicated("refresh token hash")
+		}
+	}
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = time.Now()
+	}
+	r.sessions[session.ID] = *session
+	return nil
+}
+
+func (r *MemoryRepository) FindSession(ctx context.Context, sessionID string) (*domain.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, ok := r.sessions[sessionID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &session, nil
+}
+
+func (r *MemoryRepository) RotateSession(ctx context.Context, sessionID, oldHash, newHash string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, ok := r.sessions[sessionID]
+	if !ok || session.RefreshTokenHash != oldHash || session.RevokedAt != nil {
+		return fmt.Errorf("%w: refresh token already rotated", domain.ErrConflict)
+	}
+	session.RefreshTokenHash = newHash
+	session.ExpiresAt = expiresAt
+	session.LastUsedAt = time.Now()
+	r.sessions[sessionID] = session
+	return nil
+}
+
+func (r *MemoryRepository) ListActiveSessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	var sessions []domain.Session
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil && session.ExpiresAt.After(now) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt) })
+	return sessions, nil
+}
+
+func (r *MemoryRepository) RevokeSession(ctx context.Context, sessionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if session, ok := r.sessions[sessionID]; ok && session.RevokedAt == nil {
+		now := time.Now()
+		session.RevokedAt = &now
+		r.sessions[sessionID] = session
+	}
+	return nil
+}
+
+// --- OrganizationRepository Implementation ---
+
+func (r *MemoryRepository) FindOrganization(ctx context.Context, orgID string) (*domain.Organization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	org, ok := r.orgs[orgID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &org, nil
+}
+
+func (r *MemoryRepository) AddUserToOrg(ctx context.Context, userOrg *domain.UserOrganization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.insertMember(userOrg)
+}
+
+func (r *MemoryRepository) CreateUserAndAddToOrg(ctx context.Context, user *domain.User, userOrg *domain.UserOrganization) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkNewUser(user); err != nil {
+		return err
+	}
+	_ = user.BeforeCreate(nil)
+	if _, ok := r.members[memberKey{userOrg.OrganizationID, user.ID}]; ok {
+		return duplicated("membership")
+	}
+	r.insertUser(user, time.Now())
+	userOrg.UserID = user.ID
+	return r.insertMember(userOrg)
+}
+
+func (r *MemoryRepository) ListOrgStaff(ctx context.Context, orgID string) ([]domain.UserOrganization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var members []domain.UserOrganization
+	for _, member := range r.members {
+		if member.OrganizationID == orgID {
+			members = append(members, member)
+		}
+	}
+	sort.SliceStable(members, func(i, j int) bool { return members[i].JoinedAt.Before(members[j].JoinedAt) })
+	return members, nil
+}
+
+func (r *MemoryRepository) FindUserOrg(ctx context.Context, userID, orgID string) (*domain.UserOrganization, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	member, ok := r.members[memberKey{orgID, userID}]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &member, nil
+}
+
+func (r *MemoryRepository) UpdateUserStatus(ctx context.Context, userID, orgID string, status domain.MemberStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := memberKey{orgID, userID}
+	if member, ok := r.members[key]; ok {
+		member.Status = status
+		r.members[key] = member
+	}
+	return nil
+}
+
+// checkNewUser aplica la restricción unique de users.email (incluye usuarios dados de baja)
+func (r *MemoryRepository) checkNewUser(user *domain.User) error {
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			return duplicated("user email")
+		}
+	}
+	if _, ok := r.users[user.ID]; ok && user.ID != "" {
+		return duplicated("user id")
+	}
+	return nil
+}
+
+func (r *MemoryRepository) insertUser(user *domain.User, now time.Time) {
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = now
+	}
+	r.users[user.ID] = *user
+}
+
+func (r *MemoryRepository) insertMember(userOrg *domain.UserOrganization) error {
+	key := memberKey{userOrg.OrganizationID, userOrg.UserID}
+	if _, ok := r.members[key]; ok {
+		return duplicated("membership")
+	}
+	if userOrg.Status == "" {
+		userOrg.Status = domain.MemberActivo // default:'Activo'
+	}
+	r.members[key] = *userOrg
+	return nil
+}
+
+// --- AuditRepository Implementation ---
+
+func (r *MemoryRepository) CreateAudit(ctx context.Context, audit *domain.Audit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_ = audit.BeforeCreate(nil)
+	if _, ok := r.audits[audit.ID]; ok {
+		return duplicated("audit id")
+	}
+	if audit.Status == "" {
+		audit.Status = domain.AuditPlanificada // default:'Planificada'
+	}
+	now := time.Now()
+	if audit.CreatedAt.IsZero() {
+		audit.CreatedAt = now
+	}
+	audit.UpdatedAt = now
+	r.audits[audit.ID] = *audit
+	return nil
+}
+
+func (r *MemoryRepository) AssignUserToAudit(ctx context.Context, assignment *domain.AuditAssignment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.assignUser(assignment)
+}
+
+func (r *MemoryRepository) ReplaceAuditAssignment(ctx context.Context, declined, replacement *domain.AuditAssignment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := memberKey{declined.AuditID, declined.UserID}
+	current, ok := r.assignments[key]
+	if !ok || !current.IsActive || current.AcceptanceStatus != domain.AcceptRechazado {
+		return fmt.Errorf("%w: assignment is no longer a rejected one", domain.ErrConflict)
+	}
+	if err := r.assignUser(replacement); err != nil {
+		return err
+	}
+	current.IsActive = false
+	r.assignments[key] = current
+	declined.IsActive = false
+	return nil
+}
+
+// assignUser exige r.mu tomado. Reactiva una asignación inactiva del mismo usuario, como Postgres.
+func (r *MemoryRepository) assignUser(assignment *domain.AuditAssignment) error {
+	key := memberKey{assignment.AuditID, assignment.UserID}
+	if existing, ok := r.assignments[key]; ok && existing.IsActive {
+		return duplicated("audit assignment")
+	}
+	// GORM omite los campos en su valor cero que tienen default: un IsActive=false
+	// se inserta como true, igual que en Postgres
+	if assignment.AcceptanceStatus == "" {
+		assignment.AcceptanceStatus = domain.AcceptPendiente
+	}
+	assignment.IsActive = true
+	r.assignments[key] = *assignment
+	return nil
+}
+
+func (r *MemoryRepository) GetAuditsByUserID(ctx context.Context, userID string, acceptance domain.AcceptanceStatus) ([]domain.Audit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Equivalente al JOIN con audit_assignments: una fila por asignación activa del usuario
+	var audits []domain.Audit
+	for _, assignment := range r.assignments {
+		if assignment.UserID != userID || !assignment.IsActive {
+			continue
+		}
+		if acceptance != "" && assignment.AcceptanceStatus != acceptance {
+			continue
+		}
+		if audit, ok := r.audits[assignment.AuditID]; ok {
+			audits = append(audits, audit)
+		}
+	}
+	sortAudits(audits, false)
+	return audits, nil
+}
+
+func (r *MemoryRepository) GetAuditByID(ctx context.Context, auditID string) (*domain.Audit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	audit, ok := r.audits[auditID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &audit, nil
+}
+
+func (r *MemoryRepository) ListAuditsForAuditee(ctx context.Context, auditeeOrgID string) ([]domain.Audit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var audits []domain.Audit
+	for _, audit := range r.audits {
+		if audit.AuditeeOrgID == auditeeOrgID {
+			audits = append(audits, audit)
+		}
+	}
+	sortAudits(audits, true)
+	return audits, nil
+}
+
+func (r *MemoryRepository) GetAuditForAuditee(ctx context.Context, auditeeOrgID, auditID string) (*domain.Audit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	audit, ok := r.audits[auditID]
+	if !ok || audit.AuditeeOrgID != auditeeOrgID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &audit, nil
+}
+
+func (r *MemoryRepository) FindAuditAssignment(ctx context.Context, auditID, userID string) (*domain.AuditAssignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	assignment, ok := r.assignments[memberKey{auditID, userID}]
+	if !ok {
+		return &domain.AuditAssignment{}, gorm.ErrRecordNotFound
+	}
+	return &assignment, nil
+}
+
+func (r *MemoryRepository) ListAuditAssignments(ctx context.Context, auditID string) ([]domain.AuditAssignment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var assignments []domain.AuditAssignment
+	for _, assignment := range r.assignments {
+		if assignment.AuditID == auditID {
+			assignments = append(assignments, assignment)
+		}
+	}
+	sort.SliceStable(assignments, func(i, j int) bool { return assignments[i].UserID < assignments[j].UserID })
+	return assignments, nil
+}
+
+// UpdateAuditAssignment guarda todos los campos, como gorm.Save (alta si no existía)
+func (r *MemoryRepository) UpdateAuditAssignment(ctx context.Context, assignment *domain.AuditAssignment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.assignments[memberKey{assignment.AuditID, assignment.UserID}] = *assignment
+	return nil
+}
+
+func (r *MemoryRepository) UpdateAuditConclusions(ctx context.Context, auditID, conclusions string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if audit, ok := r.audits[auditID]; ok {
+		audit.Conclusions = conclusions
+		audit.UpdatedAt = time.Now()
+		r.audits[auditID] = audit
+	}
+	return nil
+}
+
+// --- Checklist ---
+
+func (r *MemoryRepository) CreateChecklistItems(ctx context.Context, items []domain.ChecklistItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Todo o nada, como el INSERT en lote
+	for i := range items {
+		_ = items[i].BeforeCreate(nil)
+		if _, ok := r.checklist[items[i].ID]; ok {
+			return duplicated("checklist item id")
+		}
+	}
+	now := time.Now()
+	for i := range items {
+		if items[i].Answer == "" {
+			items[i].Answer = domain.AnswerPendiente // default:'Pendiente'
+		}
+		if items[i].CreatedAt.IsZero() {
+			items[i].CreatedAt = now
+		}
+		items[i].UpdatedAt = now
+		r.checklist[items[i].ID] = items[i]
+	}
+	return nil
+}
+
+func (r *MemoryRepository) GetChecklistByAuditID(ctx context.Context, auditID string) ([]domain.ChecklistItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var items []domain.ChecklistItem
+	for _, item := range r.checklist {
+		if item.AuditID == auditID {
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Standard != items[j].Standard {
+			return items[i].Standard < items[j].Standard
+		}
+		return items[i].ClauseNumber < items[j].ClauseNumber
+	})
+	return items, nil
+}
+
+func (r *MemoryRepository) FindChecklistItem(ctx context.Context, auditID, itemID string) (*domain.ChecklistItem, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.checklist[itemID]
+	if !ok || item.AuditID != auditID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &item, nil
+}
+
+func (r *MemoryRepository) UpdateChecklistItem(ctx context.Context, item *domain.ChecklistItem) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item.UpdatedAt = time.Now()
+	r.checklist[item.ID] = *item
+	return nil
+}
+
+// --- Temporary links ---
+
+func (r *MemoryRepository) CreateTemporaryLink(ctx context.Context, link *domain.TemporaryLink) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_ = link.BeforeCreate(nil)
+	if _, ok := r.links[link.ID]; ok {
+		return duplicated("temporary link id")
+	}
+	for _, l := range r.links {
+		if l.TokenHash == link.TokenHash {
+			return duplicated("temporary link token hash")
+		}
+	}
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt = time.Now()
+	}
+	r.links[link.ID] = *link
+	return nil
+}
+
+func (r *MemoryRepository) FindTemporaryLink(ctx context.Context, linkID string) (*domain.TemporaryLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	link, ok := r.links[linkID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &link, nil
+}
+
+func (r *MemoryRepository) FindTemporaryLinkByHash(ctx context.Context, tokenHash string) (*domain.TemporaryLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, link := range r.links {
+		if link.TokenHash == tokenHash {
+			return &link, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *MemoryRepository) ListTemporaryLinks(ctx context.Context, auditID string) ([]domain.TemporaryLink, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var links []domain.TemporaryLink
+	for _, link := range r.links {
+		if link.AuditID == auditID {
+			links = append(links, link)
+		}
+	}
+	sort.SliceStable(links, func(i, j int) bool { return links[i].CreatedAt.After(links[j].CreatedAt) })
+	return links, nil
+}
+
+func (r *MemoryRepository) RevokeTemporaryLink(ctx context.Context, auditID, linkID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if link, ok := r.links[linkID]; ok && link.AuditID == auditID && link.RevokedAt == nil {
+		now := time.Now()
+		link.RevokedAt = &now
+		r.links[linkID] = link
+	}
+	return nil
+}
+
+func (r *MemoryRepository) RegisterTemporaryLinkUse(ctx context.Context, linkID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	link, ok := r.links[linkID]
+	if !ok || !link.IsUsable(now) {
+		return fmt.Errorf("%w: temporary link is no longer usable", domain.ErrForbidden)
+	}
+	link.UseCount++
+	link.LastUsedAt = &now
+	r.links[linkID] = link
+	return nil
+}
+
+// --- Lifecycle ---
+
+func (r *MemoryRepository) UpdateAuditStatus(ctx context.Context, change *domain.AuditStatusChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	audit, ok := r.audits[change.AuditID]
+	if !ok || audit.Status != change.FromStatus {
+		return fmt.Errorf("%w: audit %s is no longer in status %s", domain.ErrConflict, change.AuditID, change.FromStatus)
+	}
+	_ = change.BeforeCreate(nil)
+	if change.CreatedAt.IsZero() {
+		change.CreatedAt = time.Now()
+	}
+	audit.Status = change.ToStatus
+	audit.UpdatedAt = change.CreatedAt
+	r.audits[audit.ID] = audit
+	r.history = append(r.history, *change)
+	return nil
+}
+
+func (r *MemoryRepository) ListAuditStatusHistory(ctx context.Context, auditID string) ([]domain.AuditStatusChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var history []domain.AuditStatusChange
+	for _, change := range r.history {
+		if change.AuditID == auditID {
+			history = append(history, change)
+		}
+	}
+	return history, nil
+}
+
+// sortAudits ordena por fecha de alta (desc = más recientes primero)
+func sortAudits(audits []domain.Audit, desc bool) {
+	sort.SliceStable(audits, func(i, j int) bool {
+		if desc {
+			return audits[i].CreatedAt.After(audits[j].CreatedAt)
+		}
+		return audits[i].CreatedAt.Before(audits[j].CreatedAt)
+	})
+}
+
+// --- ReportTemplateRepository Implementation ---
+
+func (r *MemoryRepository) GetReportTemplate(ctx context.Context, orgID string) (*domain.ReportTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tpl, ok := r.templates[orgID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &tpl, nil
+}
+
+func (r *MemoryRepository) SaveReportTemplate(ctx context.Context, tpl *domain.ReportTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tpl.UpdatedAt = time.Now()
+	r.templates[tpl.OrganizationID] = *tpl
+	return nil
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/repository/repotest"
+)
+
+func TestMemoryContract(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Store { return NewMemoryRepository() })
+}
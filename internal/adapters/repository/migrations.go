@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+// Migraciones versionadas: migrations/<motor>/<versión>_<nombre>.up.sql y su .down.sql.
+// Cada una corre en su propia transacción junto con el alta en schema_migrations.
+//
+//go:embed migrations
+var migrationFiles embed.FS
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus es una migración conocida por este binario o registrada en la base.
+// AppliedAt nil = pendiente; Unknown = aplicada por una versión más nueva del binario.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	Unknown   bool
+}
+
+type schemaMigration struct {
+	Version   int64 `gorm:"primaryKey"`
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string { return "schema_migrations" }
+
+// Migrator aplica y revierte las migraciones del motor de db
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func loadMigrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no hay migraciones para el motor %q: %w", dialect, err)
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("nombre de migración inválido: %s", entry.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		body, err := fs.ReadFile(migrationFiles, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m := byVersion[version]
+		if m == nil {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("la migración %d tiene dos nombres: %s y %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("la migración %d_%s necesita los archivos up y down", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Up aplica las migraciones pendientes en orden y devuelve las aplicadas
+func (m *Migrator) Up() ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(func(conn *gorm.DB) error {
+		done, err := appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+		// Una base sin historial puede venir de AutoMigrate: se adopta junto con la primera migración
+		adopt := len(done) == 0
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if adopt {
+					if err := adoptLegacySchema(tx); err != nil {
+						return err
+					}
+				}
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now().UTC(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migración %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			adopt = false
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down revierte las últimas steps migraciones aplicadas, de la más nueva a la más vieja
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	known := map[int64]Migration{}
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	var reverted []Migration
+	err := m.withLock(func(conn *gorm.DB) error {
+		var rows []schemaMigration
+		if err := conn.Order("version DESC").Limit(steps).Find(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			migration, ok := known[row.Version]
+			if !ok {
+				return fmt.Errorf("la migración %d_%s no está en este binario: no se puede revertir", row.Version, row.Name)
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{}, "version = ?", migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("revirtiendo %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lista las migraciones ordenadas por versión con su estado en la base
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	var status []MigrationStatus
+	err := m.withLock(func(conn *gorm.DB) error {
+		done, err := appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			entry := MigrationStatus{Version: migration.Version, Name: migration.Name}
+			if row, ok := done[migration.Version]; ok {
+				entry.AppliedAt = &row.AppliedAt
+				delete(done, migration.Version)
+			}
+			status = append(status, entry)
+		}
+		for _, row := range done {
+			status = append(status, MigrationStatus{Version: row.Version, Name: row.Name, AppliedAt: &row.AppliedAt, Unknown: true})
+		}
+		return nil
+	})
+	sort.Slice(status, func(i, j int) bool { return status[i].Version < status[j].Version })
+	return status, err
+}
+
+// withLock crea schema_migrations si falta y ejecuta fn en una única conexión. En Postgres
+// toma un advisory lock de sesión: si arrancan varias réplicas a la vez, migra una sola y
+// las demás esperan y encuentran todo aplicado. SQLite trabaja con una sola conexión.
+func (m *Migrator) withLock(fn func(conn *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		// Cada operación arranca de una sentencia limpia (sin la tabla de la anterior)
+		conn = conn.Session(&gorm.Session{NewDB: true})
+		postgres := conn.Dialector.Name() == "postgres"
+		if postgres {
+			if err := conn.Exec("SELECT pg_advisory_lock(hashtext('schema_migrations'))").Error; err != nil {
+				return err
+			}
+			defer conn.Exec("SELECT pg_advisory_unlock(hashtext('schema_migrations'))")
+		}
+
+		timestamp := "datetime"
+		if postgres {
+			timestamp = "timestamptz"
+		}
+		if err := conn.Exec(`CREATE TABLE IF NOT EXISTS "schema_migrations" (
+    "version" bigint PRIMARY KEY,
+    "name" text NOT NULL,
+    "applied_at" ` + timestamp + ` NOT NULL
+)`).Error; err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+// adoptLegacySchema lleva al esquema actual las tablas de una base creada por AutoMigrate,
+// desde la primera versión del proyecto en adelante. Las migraciones crean solo lo que falta
+// (IF NOT EXISTS): sin este paso, esas tablas quedarían sin las columnas agregadas después.
+func adoptLegacySchema(db *gorm.DB) error {
+	m := db.Migrator()
+
+	// revoked_tokens guardaba el token en claro y ahora guarda el jti. Esas filas ya no
+	// sirven (los tokens sin jti se rechazan): se descarta la tabla y se vuelve a crear vacía.
+	if m.HasColumn(&domain.RevokedToken{}, "token") {
+		if err := m.DropTable(&domain.RevokedToken{}); err != nil {
+			return err
+		}
+	}
+
+	// Los enlaces temporales pasaron de audit_assignments (UUID en claro, sin vencimiento)
+	// a su propia tabla con hash: los enlaces viejos dejan de ser válidos
+	if m.HasColumn(&domain.AuditAssignment{}, "temporary_link") {
+		if err := m.DropColumn(&domain.AuditAssignment{}, "temporary_link"); err != nil {
+			return err
+		}
+	}
+
+	// Columnas agregadas a tablas que ya existían (ej: audits.auditee_org_id)
+	for _, model := range schemaModels {
+		if !m.HasTable(model) {
+			continue
+		}
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" || field.IgnoreMigration || m.HasColumn(model, field.DBName) {
+				continue
+			}
+			if err := m.AddColumn(model, field.Name); err != nil {
+				return fmt.Errorf("agregando %s.%s: %w", stmt.Schema.Table, field.DBName, err)
+			}
+		}
+	}
+	return nil
+}
+
+func appliedMigrations(conn *gorm.DB) (map[int64]schemaMigration, error) {
+	var rows []schemaMigration
+	if err := conn.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	done := make(map[int64]schemaMigration, len(rows))
+	for _, row := range rows {
+		done[row.Version] = row
+	}
+	return done, nil
+}
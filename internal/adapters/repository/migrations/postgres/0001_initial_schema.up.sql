@@ -0,0 +1,390 @@
+-- Esquema base: el que generaba AutoMigrate hasta la incorporación de las migraciones
+-- versionadas. IF NOT EXISTS solo completa lo que falta: en una base creada por AutoMigrate,
+-- el Migrator agrega antes las columnas nuevas a las tablas existentes (adoptLegacySchema).
+
+CREATE TABLE IF NOT EXISTS "organizations" (
+    "id" text,
+    "name" text NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" text,
+    "email" text NOT NULL,
+    "password" text NOT NULL,
+    "created_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_users_email" UNIQUE ("email")
+);
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+
+CREATE TABLE IF NOT EXISTS "user_organizations" (
+    "user_id" text,
+    "organization_id" text,
+    "role_default" text NOT NULL,
+    "status" text DEFAULT 'Activo',
+    "joined_at" timestamptz,
+    PRIMARY KEY ("user_id", "organization_id")
+);
+
+CREATE TABLE IF NOT EXISTS "clients" (
+    "id" text,
+    "consultora_id" text NOT NULL,
+    "client_org_id" text NOT NULL,
+    "sites" text,
+    "contact_name" text,
+    "contact_email" text,
+    "contact_phone" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_clients_client_org_id" ON "clients" ("client_org_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_client_pair" ON "clients" ("consultora_id", "client_org_id");
+
+CREATE TABLE IF NOT EXISTS "audits" (
+    "id" text,
+    "title" text NOT NULL,
+    "org_owner_id" text NOT NULL,
+    "auditee_org_id" text,
+    "checklist_template_id" text,
+    "status" text DEFAULT 'Planificada',
+    "conclusions" text,
+    "planned_start" timestamptz,
+    "planned_end" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audits_auditee_org_id" ON "audits" ("auditee_org_id");
+CREATE INDEX IF NOT EXISTS "idx_audits_org_owner_id" ON "audits" ("org_owner_id");
+
+CREATE TABLE IF NOT EXISTS "audit_assignments" (
+    "audit_id" text,
+    "user_id" text,
+    "role_in_audit" text NOT NULL,
+    "acceptance_status" text DEFAULT 'Pendiente',
+    "is_active" boolean DEFAULT true,
+    "rejection_reason" text,
+    "responded_at" timestamptz,
+    PRIMARY KEY ("audit_id", "user_id")
+);
+
+CREATE TABLE IF NOT EXISTS "audit_sessions" (
+    "id" text,
+    "audit_id" text NOT NULL,
+    "starts_at" timestamptz NOT NULL,
+    "ends_at" timestamptz NOT NULL,
+    "site" text,
+    "process" text NOT NULL,
+    "auditee" text,
+    "auditor_id" text NOT NULL,
+    "notes" text,
+    "created_by" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_sessions_auditor_id" ON "audit_sessions" ("auditor_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_sessions_ends_at" ON "audit_sessions" ("ends_at");
+CREATE INDEX IF NOT EXISTS "idx_audit_sessions_starts_at" ON "audit_sessions" ("starts_at");
+CREATE INDEX IF NOT EXISTS "idx_audit_sessions_audit_id" ON "audit_sessions" ("audit_id");
+
+CREATE TABLE IF NOT EXISTS "audit_programmes" (
+    "id" text,
+    "organization_id" text NOT NULL,
+    "name" text NOT NULL,
+    "start_year" bigint NOT NULL,
+    "end_year" bigint NOT NULL,
+    "objectives" text,
+    "processes" text,
+    "clauses" text,
+    "created_by" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_programmes_organization_id" ON "audit_programmes" ("organization_id");
+
+CREATE TABLE IF NOT EXISTS "programme_entries" (
+    "id" text,
+    "programme_id" text NOT NULL,
+    "period" text NOT NULL,
+    "title" text NOT NULL,
+    "processes" text,
+    "clauses" text,
+    "client_id" text,
+    "audit_id" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_programme_entries_audit_id" ON "programme_entries" ("audit_id");
+CREATE INDEX IF NOT EXISTS "idx_programme_entries_period" ON "programme_entries" ("period");
+CREATE INDEX IF NOT EXISTS "idx_programme_entries_programme_id" ON "programme_entries" ("programme_id");
+
+CREATE TABLE IF NOT EXISTS "revoked_tokens" (
+    "id" bigserial,
+    "jti" text NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_revoked_tokens_expires_at" ON "revoked_tokens" ("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_revoked_tokens_jti" ON "revoked_tokens" ("jti");
+
+CREATE TABLE IF NOT EXISTS "sessions" (
+    "id" text,
+    "user_id" text NOT NULL,
+    "organization_id" text NOT NULL,
+    "role" text NOT NULL,
+    "refresh_token_hash" text NOT NULL,
+    "user_agent" text,
+    "ip" text,
+    "created_at" timestamptz,
+    "last_used_at" timestamptz,
+    "expires_at" timestamptz NOT NULL,
+    "revoked_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_sessions_expires_at" ON "sessions" ("expires_at");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_sessions_refresh_token_hash" ON "sessions" ("refresh_token_hash");
+CREATE INDEX IF NOT EXISTS "idx_sessions_user_id" ON "sessions" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "calendar_feeds" (
+    "user_id" text,
+    "token_hash" text NOT NULL,
+    "created_at" timestamptz,
+    "last_fetched_at" timestamptz,
+    PRIMARY KEY ("user_id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_calendar_feeds_token_hash" ON "calendar_feeds" ("token_hash");
+
+CREATE TABLE IF NOT EXISTS "temporary_links" (
+    "id" text,
+    "audit_id" text NOT NULL,
+    "user_id" text NOT NULL,
+    "token_hash" text NOT NULL,
+    "scopes" text NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "max_uses" bigint,
+    "use_count" bigint DEFAULT 0,
+    "last_used_at" timestamptz,
+    "revoked_at" timestamptz,
+    "created_by" text NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_temporary_links_token_hash" ON "temporary_links" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_temporary_links_user_id" ON "temporary_links" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_temporary_links_audit_id" ON "temporary_links" ("audit_id");
+
+CREATE TABLE IF NOT EXISTS "standards" (
+    "id" text,
+    "code" text NOT NULL,
+    "version" text NOT NULL,
+    "organization_id" text,
+    "title" text,
+    "created_by" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_standard_version" ON "standards" ("code", "version", "organization_id");
+
+CREATE TABLE IF NOT EXISTS "clauses" (
+    "id" text,
+    "standard_id" text NOT NULL,
+    "number" text NOT NULL,
+    "parent_number" text,
+    "title" text,
+    "requirement" text,
+    "expected_evidence" text,
+    "position" bigint NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_clauses_standard_id" ON "clauses" ("standard_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_clause_number" ON "clauses" ("standard_id", "number");
+
+CREATE TABLE IF NOT EXISTS "checklist_templates" (
+    "id" text,
+    "organization_id" text NOT NULL,
+    "family_id" text NOT NULL,
+    "version" bigint NOT NULL,
+    "name" text NOT NULL,
+    "description" text,
+    "created_by" text NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_template_version" ON "checklist_templates" ("family_id", "version");
+CREATE INDEX IF NOT EXISTS "idx_checklist_templates_organization_id" ON "checklist_templates" ("organization_id");
+
+CREATE TABLE IF NOT EXISTS "checklist_template_items" (
+    "id" text,
+    "template_id" text NOT NULL,
+    "position" bigint NOT NULL,
+    "clause_id" text,
+    "standard" text NOT NULL,
+    "clause_number" text NOT NULL,
+    "requirement" text NOT NULL,
+    "expected_evidence" text,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_checklist_template_items_template_id" ON "checklist_template_items" ("template_id");
+
+CREATE TABLE IF NOT EXISTS "checklist_items" (
+    "id" text,
+    "audit_id" text NOT NULL,
+    "standard" text NOT NULL,
+    "clause_number" text NOT NULL,
+    "requirement" text NOT NULL,
+    "expected_evidence" text,
+    "clause_id" text,
+    "answer" text DEFAULT 'Pendiente',
+    "notes" text,
+    "answered_by" text,
+    "answered_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_checklist_items_clause_id" ON "checklist_items" ("clause_id");
+CREATE INDEX IF NOT EXISTS "idx_checklist_items_audit_id" ON "checklist_items" ("audit_id");
+
+CREATE TABLE IF NOT EXISTS "findings" (
+    "id" text,
+    "audit_id" text NOT NULL,
+    "checklist_item_id" text,
+    "severity" text NOT NULL,
+    "description" text NOT NULL,
+    "evidence_ref" text,
+    "reported_by" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_findings_deleted_at" ON "findings" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_findings_reported_by" ON "findings" ("reported_by");
+CREATE INDEX IF NOT EXISTS "idx_findings_checklist_item_id" ON "findings" ("checklist_item_id");
+CREATE INDEX IF NOT EXISTS "idx_findings_audit_id" ON "findings" ("audit_id");
+
+CREATE TABLE IF NOT EXISTS "corrective_actions" (
+    "id" text,
+    "finding_id" text NOT NULL,
+    "audit_id" text NOT NULL,
+    "root_cause" text,
+    "action_plan" text,
+    "responsible_user_id" text NOT NULL,
+    "responsible_org_id" text NOT NULL,
+    "due_date" timestamptz NOT NULL,
+    "status" text DEFAULT 'Abierta',
+    "review_notes" text,
+    "created_by" text NOT NULL,
+    "implemented_at" timestamptz,
+    "verified_by" text,
+    "verified_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_corrective_actions_responsible_org_id" ON "corrective_actions" ("responsible_org_id");
+CREATE INDEX IF NOT EXISTS "idx_corrective_actions_responsible_user_id" ON "corrective_actions" ("responsible_user_id");
+CREATE INDEX IF NOT EXISTS "idx_corrective_actions_audit_id" ON "corrective_actions" ("audit_id");
+CREATE INDEX IF NOT EXISTS "idx_corrective_actions_finding_id" ON "corrective_actions" ("finding_id");
+
+CREATE TABLE IF NOT EXISTS "audit_status_changes" (
+    "id" text,
+    "audit_id" text NOT NULL,
+    "from_status" text NOT NULL,
+    "to_status" text NOT NULL,
+    "changed_by" text NOT NULL,
+    "reason" text,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_audit_status_changes_audit_id" ON "audit_status_changes" ("audit_id");
+
+CREATE TABLE IF NOT EXISTS "evidences" (
+    "id" text,
+    "audit_id" text NOT NULL,
+    "finding_id" text,
+    "checklist_item_id" text,
+    "uploaded_by" text NOT NULL,
+    "file_name" text NOT NULL,
+    "content_type" text NOT NULL,
+    "size" bigint NOT NULL,
+    "sha256" text NOT NULL,
+    "storage_key" text NOT NULL,
+    "created_at" timestamptz,
+    "deleted_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_evidences_deleted_at" ON "evidences" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_evidences_sha256" ON "evidences" ("sha256");
+CREATE INDEX IF NOT EXISTS "idx_evidences_uploaded_by" ON "evidences" ("uploaded_by");
+CREATE INDEX IF NOT EXISTS "idx_evidences_checklist_item_id" ON "evidences" ("checklist_item_id");
+CREATE INDEX IF NOT EXISTS "idx_evidences_finding_id" ON "evidences" ("finding_id");
+CREATE INDEX IF NOT EXISTS "idx_evidences_audit_id" ON "evidences" ("audit_id");
+
+CREATE TABLE IF NOT EXISTS "report_templates" (
+    "organization_id" text,
+    "title" text,
+    "header" text,
+    "intro" text,
+    "footer" text,
+    "html_template" text,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("organization_id")
+);
+
+CREATE TABLE IF NOT EXISTS "invitations" (
+    "id" text,
+    "organization_id" text NOT NULL,
+    "email" text NOT NULL,
+    "role" text NOT NULL,
+    "token_hash" text NOT NULL,
+    "invited_by" text NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "accepted_at" timestamptz,
+    "revoked_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_invitations_token_hash" ON "invitations" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_invitations_email" ON "invitations" ("email");
+CREATE INDEX IF NOT EXISTS "idx_invitations_organization_id" ON "invitations" ("organization_id");
+
+CREATE TABLE IF NOT EXISTS "activity_entries" (
+    "id" text,
+    "organization_id" text NOT NULL,
+    "seq" bigint NOT NULL,
+    "actor_id" text,
+    "entity_type" text NOT NULL,
+    "entity_id" text NOT NULL,
+    "action" text NOT NULL,
+    "changes" text,
+    "request_id" text,
+    "ip" text,
+    "created_at" timestamptz NOT NULL,
+    "prev_hash" text NOT NULL,
+    "hash" text NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_activity_entries_created_at" ON "activity_entries" ("created_at");
+CREATE INDEX IF NOT EXISTS "idx_activity_entries_request_id" ON "activity_entries" ("request_id");
+CREATE INDEX IF NOT EXISTS "idx_activity_entity" ON "activity_entries" ("entity_type", "entity_id");
+CREATE INDEX IF NOT EXISTS "idx_activity_entries_actor_id" ON "activity_entries" ("actor_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_activity_seq" ON "activity_entries" ("organization_id", "seq");
+
+-- La bitácora es de solo inserción también para quien acceda directo a la base
+CREATE OR REPLACE FUNCTION activity_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'activity_entries es de solo inserción';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS activity_entries_append_only ON "activity_entries";
+CREATE TRIGGER activity_entries_append_only BEFORE UPDATE OR DELETE ON "activity_entries"
+FOR EACH ROW EXECUTE FUNCTION activity_entries_append_only();
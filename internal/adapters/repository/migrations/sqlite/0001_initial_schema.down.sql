@@ -0,0 +1,28 @@
+DROP TRIGGER IF EXISTS activity_entries_append_only_UPDATE;
+DROP TRIGGER IF EXISTS activity_entries_append_only_DELETE;
+
+DROP TABLE IF EXISTS "activity_entries";
+DROP TABLE IF EXISTS "invitations";
+DROP TABLE IF EXISTS "report_templates";
+DROP TABLE IF EXISTS "evidences";
+DROP TABLE IF EXISTS "audit_status_changes";
+DROP TABLE IF EXISTS "corrective_actions";
+DROP TABLE IF EXISTS "findings";
+DROP TABLE IF EXISTS "checklist_items";
+DROP TABLE IF EXISTS "checklist_template_items";
+DROP TABLE IF EXISTS "checklist_templates";
+DROP TABLE IF EXISTS "clauses";
+DROP TABLE IF EXISTS "standards";
+DROP TABLE IF EXISTS "temporary_links";
+DROP TABLE IF EXISTS "calendar_feeds";
+DROP TABLE IF EXISTS "sessions";
+DROP TABLE IF EXISTS "revoked_tokens";
+DROP TABLE IF EXISTS "programme_entries";
+DROP TABLE IF EXISTS "audit_programmes";
+DROP TABLE IF EXISTS "audit_sessions";
+DROP TABLE IF EXISTS "audit_assignments";
+DROP TABLE IF EXISTS "audits";
+DROP TABLE IF EXISTS "clients";
+DROP TABLE IF EXISTS "user_organizations";
+DROP TABLE IF EXISTS "users";
+DROP TABLE IF EXISTS "organizations";
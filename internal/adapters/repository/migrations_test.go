@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"gorm.io/gorm"
+)
+
+// Tablas tal como las creaba AutoMigrate en la primera versión del proyecto
+type legacyAudit struct {
+	ID         string `gorm:"primaryKey"`
+	Title      string `gorm:"not null"`
+	OrgOwnerID string `gorm:"not null;index"`
+	Status     string `gorm:"default:'Planificada'"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (legacyAudit) TableName() string { return "audits" }
+
+type legacyAuditAssignment struct {
+	AuditID          string `gorm:"primaryKey"`
+	UserID           string `gorm:"primaryKey"`
+	RoleInAudit      string `gorm:"not null"`
+	AcceptanceStatus string `gorm:"default:'Pendiente'"`
+	IsActive         bool   `gorm:"default:true"`
+	TemporaryLink    string `gorm:"index"`
+}
+
+func (legacyAuditAssignment) TableName() string { return "audit_assignments" }
+
+type legacyRevokedToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	Token     string    `gorm:"index;not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+func (legacyRevokedToken) TableName() string { return "revoked_tokens" }
+
+func TestMigrateFreshSQLite(t *testing.T) {
+	db, err := ConnectSQLite(filepath.Join(t.TempDir(), "db.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testMigrateFresh(t, db)
+}
+
+func TestMigrateLegacySQLite(t *testing.T) {
+	db, err := ConnectSQLite(filepath.Join(t.TempDir(), "db.sqlite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testMigrateLegacy(t, db)
+}
+
+// Las variantes de Postgres necesitan una base descartable: borran el esquema public
+func TestMigrateFreshPostgres(t *testing.T) {
+	testMigrateFresh(t, emptyPostgres(t))
+}
+
+func TestMigrateLegacyPostgres(t *testing.T) {
+	testMigrateLegacy(t, emptyPostgres(t))
+}
+
+func emptyPostgres(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN no definido")
+	}
+	db, err := ConnectPostgres(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec("DROP SCHEMA public CASCADE; CREATE SCHEMA public").Error; err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func testMigrateFresh(t *testing.T, db *gorm.DB) {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applied, err := migrator.Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) == 0 {
+		t.Fatal("no migration applied on an empty database")
+	}
+	assertCurrentSchema(t, db)
+
+	reverted, err := migrator.Down(len(applied))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reverted) != len(applied) {
+		t.Fatalf("reverted %d migrations, want %d", len(reverted), len(applied))
+	}
+	if db.Migrator().HasTable("audits") {
+		t.Fatal("audits still exists after reverting every migration")
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+	assertCurrentSchema(t, db)
+}
+
+func testMigrateLegacy(t *testing.T, db *gorm.DB) {
+	if err := db.AutoMigrate(&domain.Organization{}, &domain.User{}, &domain.UserOrganization{},
+		&legacyAudit{}, &legacyAuditAssignment{}, &legacyRevokedToken{}); err != nil {
+		t.Fatal(err)
+	}
+	must(t, db.Create(&domain.Organization{ID: "org-1", Name: "Consultora"}).Error)
+	must(t, db.Create(&legacyAudit{ID: "audit-1", Title: "Auditoría 2023", OrgOwnerID: "org-1", Status: "Planificada"}).Error)
+	must(t, db.Create(&legacyAuditAssignment{AuditID: "audit-1", UserID: "user-1", RoleInAudit: "Auditor_Lider", TemporaryLink: "abc"}).Error)
+	must(t, db.Create(&legacyRevokedToken{Token: "eyJ...", ExpiresAt: time.Now().Add(time.Hour)}).Error)
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatal(err)
+	}
+	assertCurrentSchema(t, db)
+	for _, legacy := range []struct{ table, column string }{
+		{"revoked_tokens", "token"},
+		{"audit_assignments", "temporary_link"},
+	} {
+		if db.Migrator().HasColumn(legacy.table, legacy.column) {
+			t.Errorf("%s.%s was not dropped", legacy.table, legacy.column)
+		}
+	}
+
+	// Los datos siguen y el repositorio funciona sobre las columnas nuevas
+	ctx := context.Background()
+	repo := &PostgresRepository{DB: db}
+	audits, err := repo.ListOrgAudits(ctx, "org-1")
+	must(t, err)
+	if len(audits) != 1 || audits[0].Title != "Auditoría 2023" {
+		t.Fatalf("legacy audit not kept: %+v", audits)
+	}
+	assignment, err := repo.FindAuditAssignment(ctx, "audit-1", "user-1")
+	must(t, err)
+	if assignment.RoleInAudit != domain.RoleAuditorLider {
+		t.Fatalf("legacy assignment not kept: %+v", assignment)
+	}
+	must(t, repo.RevokeToken(ctx, "session-1", time.Now().Add(time.Hour)))
+	revoked, err := repo.IsTokenRevoked(ctx, "session-1")
+	must(t, err)
+	if !revoked {
+		t.Fatal("revoked jti not found after adopting the legacy schema")
+	}
+}
+
+// assertCurrentSchema verifica que cada columna de cada modelo exista en la base
+func assertCurrentSchema(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	for _, model := range schemaModels {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			t.Fatal(err)
+		}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" && !field.IgnoreMigration && !db.Migrator().HasColumn(model, field.DBName) {
+				t.Errorf("missing column %s.%s", stmt.Schema.Table, field.DBName)
+			}
+		}
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
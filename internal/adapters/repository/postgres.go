@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -14,33 +16,120 @@ type PostgresRepository struct {
 	DB *gorm.DB
 }
 
-func NewPostgresDB(dsn string) *PostgresRepository {
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+func NewPostgresDB(dsn string, autoMigrate bool) *PostgresRepository {
+	repo, err := OpenPostgres(dsn, autoMigrate)
 	if err != nil {
-		log.Fatal("No se pudo conectar a la DB")
+		log.Fatal(err)
 	}
+	fmt.Println("Conexión a DB y migración exitosa")
+	return repo
+}
 
-	// Auto-Migración de tablas
-	err = db.AutoMigrate(
-		&domain.Organization{},
-		&domain.User{},
-		&domain.UserOrganization{},
-		&domain.Audit{},
-		&domain.AuditAssignment{},
-		&domain.RevokedToken{},
-	)
+// OpenPostgres conecta y migra devolviendo el error en vez de terminar el proceso.
+// TranslateError unifica los errores de unicidad en gorm.ErrDuplicatedKey (ver MemoryRepository).
+func OpenPostgres(dsn string, autoMigrate bool) (*PostgresRepository, error) {
+	db, err := ConnectPostgres(dsn)
 	if err != nil {
-		log.Fatal("Error en la migración:", err)
+		return nil, err
+	}
+	if err := prepareSchema(db, autoMigrate); err != nil {
+		return nil, fmt.Errorf("error en la migración: %w", err)
 	}
+	return &PostgresRepository{DB: db}, nil
+}
 
-	fmt.Println("Conexión a DB y migración exitosa")
-	return &PostgresRepository{DB: db}
+// ConnectPostgres solo conecta, sin tocar el esquema (lo usa cmd/migrate)
+func ConnectPostgres(dsn string) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo conectar a la DB: %w", err)
+	}
+	return db, nil
+}
+
+// prepareSchema aplica las migraciones versionadas pendientes, o AutoMigrate si se pidió
+// el modo de desarrollo (DB_AUTO_MIGRATE)
+func prepareSchema(db *gorm.DB, auto bool) error {
+	if auto {
+		return autoMigrate(db)
+	}
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	applied, err := migrator.Up()
+	for _, m := range applied {
+		log.Printf("migración aplicada: %04d_%s", m.Version, m.Name)
+	}
+	return err
+}
+
+// schemaModels son los modelos persistidos, en el orden en que AutoMigrate crea sus tablas
+var schemaModels = []interface{}{
+	&domain.Organization{},
+	&domain.User{},
+	&domain.UserOrganization{},
+	&domain.Client{},
+	&domain.Audit{},
+	&domain.AuditAssignment{},
+	&domain.AuditSession{},
+	&domain.AuditProgramme{},
+	&domain.ProgrammeEntry{},
+	&domain.RevokedToken{},
+	&domain.Session{},
+	&domain.CalendarFeed{},
+	&domain.TemporaryLink{},
+	&domain.Standard{},
+	&domain.Clause{},
+	&domain.ChecklistTemplate{},
+	&domain.ChecklistTemplateItem{},
+	&domain.ChecklistItem{},
+	&domain.Finding{},
+	&domain.CorrectiveAction{},
+	&domain.AuditStatusChange{},
+	&domain.Evidence{},
+	&domain.ReportTemplate{},
+	&domain.Invitation{},
+	&domain.ActivityEntry{},
+}
+
+// autoMigrate deriva el esquema de los modelos. Solo para desarrollo: no renombra, no
+// completa datos ni se puede revertir; los cambios de esquema van en migrations/.
+func autoMigrate(db *gorm.DB) error {
+	// Las columnas que AutoMigrate no elimina (y revoked_tokens, que cambió de clave)
+	if err := adoptLegacySchema(db); err != nil {
+		return err
+	}
+	// Auto-Migración de tablas
+	if err := db.AutoMigrate(schemaModels...); err != nil {
+		return err
+	}
+
+	// La bitácora es de solo inserción también para quien acceda directo a la base
+	if db.Dialector.Name() == "sqlite" {
+		return sqliteAppendOnly(db)
+	}
+	if err := db.Exec(`CREATE OR REPLACE FUNCTION activity_entries_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'activity_entries es de solo inserción';
+END;
+$$ LANGUAGE plpgsql`).Error; err != nil {
+		return err
+	}
+	if err := db.Exec(`DROP TRIGGER IF EXISTS activity_entries_append_only ON activity_entries`).Error; err != nil {
+		return err
+	}
+	if err := db.Exec(`CREATE TRIGGER activity_entries_append_only BEFORE UPDATE OR DELETE ON activity_entries
+FOR EACH ROW EXECUTE FUNCTION activity_entries_append_only()`).Error; err != nil {
+		return err
+	}
+	return nil
 }
 
 // --- AuthRepository Implementation ---
 
-func (r *PostgresRepository) CreateUserWithOrg(user *domain.User, org *domain.Organization, userOrg *domain.UserOrganization) error {
-	return r.DB.Transaction(func(tx *gorm.DB) error {
+func (r *PostgresRepository) CreateUserWithOrg(ctx context.Context, user *domain.User, org *domain.Organization, userOrg *domain.UserOrganization) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(org).Error; err != nil {
 			return err
 		}
@@ -57,45 +146,126 @@ func (r *PostgresRepository) CreateUserWithOrg(user *domain.User, org *domain.Or
 	})
 }
 
-func (r *PostgresRepository) FindUserByEmail(email string) (*domain.User, error) {
+func (r *PostgresRepository) FindUserByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
-	if err := r.DB.Where("email = ?", email).First(&user).Error; err != nil {
+	if err := r.DB.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *PostgresRepository) GetUserPrimaryOrg(userID string) (*domain.UserOrganization, error) {
+func (r *PostgresRepository) FindUserByID(ctx context.Context, userID string) (*domain.User, error) {
+	var user domain.User
+	if err := r.DB.WithContext(ctx).First(&user, "id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// DeleteUser da de baja lógica al usuario (soft-delete): deja de encontrarse pero su email sigue reservado
+func (r *PostgresRepository) DeleteUser(ctx context.Context, userID string) error {
+	return r.DB.WithContext(ctx).Delete(&domain.User{}, "id = ?", userID).Error
+}
+
+func (r *PostgresRepository) GetUserPrimaryOrg(ctx context.Context, userID string) (*domain.UserOrganization, error) {
 	var userOrg domain.UserOrganization
-	// Finds the first organization (simplification for Primary)
-	if err := r.DB.Where("user_id = ?", userID).First(&userOrg).Error; err != nil {
+	// Primary = la membresía activa más antigua
+	if err := r.DB.WithContext(ctx).Where("user_id = ? AND status = ?", userID, domain.MemberActivo).
+		Order("joined_at").
+		First(&userOrg).Error; err != nil {
 		return nil, err
 	}
 	return &userOrg, nil
 }
 
-func (r *PostgresRepository) RevokeToken(token string, expirationTime int64) error {
+func (r *PostgresRepository) ListUserOrganizations(ctx context.Context, userID string) ([]domain.OrganizationMembership, error) {
+	var memberships []domain.OrganizationMembership
+	err := r.DB.WithContext(ctx).Model(&domain.UserOrganization{}).
+		Select("user_organizations.organization_id, organizations.name, user_organizations.role_default, user_organizations.status, user_organizations.joined_at").
+		Joins("JOIN organizations ON organizations.id = user_organizations.organization_id").
+		Where("user_organizations.user_id = ?", userID).
+		Order("user_organizations.joined_at").
+		Scan(&memberships).Error
+	return memberships, err
+}
+
+func (r *PostgresRepository) FindMembership(ctx context.Context, userID, orgID string) (*domain.UserOrganization, error) {
+	return r.FindUserOrg(ctx, userID, orgID)
+}
+
+func (r *PostgresRepository) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
 	revoked := domain.RevokedToken{
-		Token:     token,
-		ExpiresAt: time.Unix(expirationTime, 0),
+		JTI:       jti,
+		ExpiresAt: expiresAt,
 	}
-	return r.DB.Create(&revoked).Error
+	return r.DB.WithContext(ctx).Create(&revoked).Error
 }
 
-func (r *PostgresRepository) IsTokenRevoked(token string) (bool, error) {
+func (r *PostgresRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
 	var count int64
-	err := r.DB.Model(&domain.RevokedToken{}).Where("token = ?", token).Count(&count).Error
+	err := r.DB.WithContext(ctx).Model(&domain.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
 	return count > 0, err
 }
 
+func (r *PostgresRepository) CreateSession(ctx context.Context, session *domain.Session) error {
+	return r.DB.WithContext(ctx).Create(session).Error
+}
+
+func (r *PostgresRepository) FindSession(ctx context.Context, sessionID string) (*domain.Session, error) {
+	var session domain.Session
+	if err := r.DB.WithContext(ctx).First(&session, "id = ?", sessionID).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *PostgresRepository) RotateSession(ctx context.Context, sessionID, oldHash, newHash string, expiresAt time.Time) error {
+	res := r.DB.WithContext(ctx).Model(&domain.Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", sessionID, oldHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash": newHash,
+			"expires_at":         expiresAt,
+			"last_used_at":       time.Now(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: refresh token already rotated", domain.ErrConflict)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) ListActiveSessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	var sessions []domain.Session
+	err := r.DB.WithContext(ctx).Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *PostgresRepository) RevokeSession(ctx context.Context, sessionID string) error {
+	return r.DB.WithContext(ctx).Model(&domain.Session{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now()).Error
+}
+
 // --- OrganizationRepository Implementation ---
 
-func (r *PostgresRepository) AddUserToOrg(userOrg *domain.UserOrganization) error {
-	return r.DB.Create(userOrg).Error
+func (r *PostgresRepository) FindOrganization(ctx context.Context, orgID string) (*domain.Organization, error) {
+	var org domain.Organization
+	if err := r.DB.WithContext(ctx).First(&org, "id = ?", orgID).Error; err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (r *PostgresRepository) AddUserToOrg(ctx context.Context, userOrg *domain.UserOrganization) error {
+	return r.DB.WithContext(ctx).Create(userOrg).Error
 }
 
-func (r *PostgresRepository) CreateUserAndAddToOrg(user *domain.User, userOrg *domain.UserOrganization) error {
-	return r.DB.Transaction(func(tx *gorm.DB) error {
+func (r *PostgresRepository) CreateUserAndAddToOrg(ctx context.Context, user *domain.User, userOrg *domain.UserOrganization) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(user).Error; err != nil {
 			return err
 		}
@@ -107,76 +277,807 @@ func (r *PostgresRepository) CreateUserAndAddToOrg(user *domain.User, userOrg *d
 	})
 }
 
-func (r *PostgresRepository) ListOrgStaff(orgID string) ([]domain.UserOrganization, error) {
+func (r *PostgresRepository) ListOrgStaff(ctx context.Context, orgID string) ([]domain.UserOrganization, error) {
 	var members []domain.UserOrganization
-	if err := r.DB.Where("organization_id = ?", orgID).Find(&members).Error; err != nil {
+	if err := r.DB.WithContext(ctx).Where("organization_id = ?", orgID).Find(&members).Error; err != nil {
 		return nil, err
 	}
 	return members, nil
 }
 
-func (r *PostgresRepository) FindUserOrg(userID, orgID string) (*domain.UserOrganization, error) {
+func (r *PostgresRepository) FindUserOrg(ctx context.Context, userID, orgID string) (*domain.UserOrganization, error) {
 	var member domain.UserOrganization
-	if err := r.DB.Where("user_id = ? AND organization_id = ?", userID, orgID).First(&member).Error; err != nil {
+	if err := r.DB.WithContext(ctx).Where("user_id = ? AND organization_id = ?", userID, orgID).First(&member).Error; err != nil {
 		return nil, err
 	}
 	return &member, nil
 }
 
-func (r *PostgresRepository) UpdateUserStatus(userID, orgID string, status domain.MemberStatus) error {
-	return r.DB.Model(&domain.UserOrganization{}).
+func (r *PostgresRepository) UpdateUserStatus(ctx context.Context, userID, orgID string, status domain.MemberStatus) error {
+	return r.DB.WithContext(ctx).Model(&domain.UserOrganization{}).
 		Where("user_id = ? AND organization_id = ?", userID, orgID).
 		Update("status", status).Error
 }
 
 // --- AuditRepository Implementation ---
 
-func (r *PostgresRepository) CreateAudit(audit *domain.Audit) error {
-	return r.DB.Create(audit).Error
+func (r *PostgresRepository) CreateAudit(ctx context.Context, audit *domain.Audit) error {
+	return r.DB.WithContext(ctx).Create(audit).Error
 }
 
-func (r *PostgresRepository) AssignUserToAudit(assignment *domain.AuditAssignment) error {
-	return r.DB.Create(assignment).Error
+func (r *PostgresRepository) AssignUserToAudit(ctx context.Context, assignment *domain.AuditAssignment) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return assignUser(tx, assignment)
+	})
+}
+
+func (r *PostgresRepository) ReplaceAuditAssignment(ctx context.Context, declined, replacement *domain.AuditAssignment) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Condicional: de dos reasignaciones simultáneas del mismo lugar, solo una lo libera
+		res := tx.Model(&domain.AuditAssignment{}).
+			Where("audit_id = ? AND user_id = ? AND is_active = ? AND acceptance_status = ?",
+				declined.AuditID, declined.UserID, true, domain.AcceptRechazado).
+			Update("is_active", false)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w: assignment is no longer a rejected one", domain.ErrConflict)
+		}
+		declined.IsActive = false
+		return assignUser(tx, replacement)
+	})
 }
 
-func (r *PostgresRepository) GetAuditsByUserID(userID string) ([]domain.Audit, error) {
+// assignUser da de alta la asignación. Si el usuario ya tuvo una inactiva en la auditoría
+// (rechazada y reasignada), se reactiva con los datos nuevos: la clave es auditoría/usuario.
+func assignUser(tx *gorm.DB, assignment *domain.AuditAssignment) error {
+	if assignment.AcceptanceStatus == "" {
+		assignment.AcceptanceStatus = domain.AcceptPendiente
+	}
+	res := tx.Model(&domain.AuditAssignment{}).
+		Where("audit_id = ? AND user_id = ? AND is_active = ?", assignment.AuditID, assignment.UserID, false).
+		Updates(map[string]interface{}{
+			"role_in_audit":     assignment.RoleInAudit,
+			"acceptance_status": assignment.AcceptanceStatus,
+			"is_active":         true,
+			"rejection_reason":  assignment.RejectionReason,
+			"responded_at":      assignment.RespondedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		assignment.IsActive = true
+		return nil
+	}
+	return tx.Create(assignment).Error
+}
+
+func (r *PostgresRepository) GetAuditsByUserID(ctx context.Context, userID string, acceptance domain.AcceptanceStatus) ([]domain.Audit, error) {
 	var audits []domain.Audit
 	// JOIN simple: obtener audits donde exista un assignment activo para este userID
-	err := r.DB.Joins("JOIN audit_assignments ON audit_assignments.audit_id = audits.id").
-		Where("audit_assignments.user_id = ? AND audit_assignments.is_active = ?", userID, true).
-		Find(&audits).Error
+	query := r.DB.WithContext(ctx).Joins("JOIN audit_assignments ON audit_assignments.audit_id = audits.id").
+		Where("audit_assignments.user_id = ? AND audit_assignments.is_active = ?", userID, true)
+	if acceptance != "" {
+		query = query.Where("audit_assignments.acceptance_status = ?", acceptance)
+	}
+	err := query.Find(&audits).Error
 	return audits, err
 }
 
-func (r *PostgresRepository) GetAuditByTempLink(tempLink string) (*domain.Audit, error) {
-	var assignment domain.AuditAssignment
-	if err := r.DB.Where("temporary_link = ? AND is_active = ?", tempLink, true).First(&assignment).Error; err != nil {
+func (r *PostgresRepository) GetAuditByID(ctx context.Context, auditID string) (*domain.Audit, error) {
+	var audit domain.Audit
+	if err := r.DB.WithContext(ctx).First(&audit, "id = ?", auditID).Error; err != nil {
 		return nil, err
 	}
+	return &audit, nil
+}
 
-	// Si encontramos el assignment, devolvemos la auditoría (si no está finalizada - pending logic check)
+func (r *PostgresRepository) ListAuditsForAuditee(ctx context.Context, auditeeOrgID string) ([]domain.Audit, error) {
+	var audits []domain.Audit
+	err := r.DB.WithContext(ctx).Where("auditee_org_id = ?", auditeeOrgID).Order("created_at DESC").Find(&audits).Error
+	return audits, err
+}
+
+func (r *PostgresRepository) GetAuditForAuditee(ctx context.Context, auditeeOrgID, auditID string) (*domain.Audit, error) {
 	var audit domain.Audit
-	if err := r.DB.First(&audit, "id = ?", assignment.AuditID).Error; err != nil {
+	if err := r.DB.WithContext(ctx).Where("id = ? AND auditee_org_id = ?", auditID, auditeeOrgID).First(&audit).Error; err != nil {
 		return nil, err
 	}
+	return &audit, nil
+}
+
+func (r *PostgresRepository) FindAuditAssignment(ctx context.Context, auditID, userID string) (*domain.AuditAssignment, error) {
+	var assignment domain.AuditAssignment
+	err := r.DB.WithContext(ctx).Where("audit_id = ? AND user_id = ?", auditID, userID).First(&assignment).Error
+	return &assignment, err
+}
+
+func (r *PostgresRepository) ListAuditAssignments(ctx context.Context, auditID string) ([]domain.AuditAssignment, error) {
+	var assignments []domain.AuditAssignment
+	err := r.DB.WithContext(ctx).Where("audit_id = ?", auditID).Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *PostgresRepository) UpdateAuditAssignment(ctx context.Context, assignment *domain.AuditAssignment) error {
+	return r.DB.WithContext(ctx).Save(assignment).Error
+}
+
+func (r *PostgresRepository) UpdateAuditConclusions(ctx context.Context, auditID, conclusions string) error {
+	return r.DB.WithContext(ctx).Model(&domain.Audit{}).Where("id = ?", auditID).Update("conclusions", conclusions).Error
+}
 
-	if audit.Status == domain.AuditFinalizada {
-		return nil, fmt.Errorf("audit is finalized")
+// --- Checklist ---
+
+func (r *PostgresRepository) CreateChecklistItems(ctx context.Context, items []domain.ChecklistItem) error {
+	if len(items) == 0 {
+		return nil
 	}
+	return r.DB.WithContext(ctx).Create(&items).Error
+}
 
-	return &audit, nil
+func (r *PostgresRepository) GetChecklistByAuditID(ctx context.Context, auditID string) ([]domain.ChecklistItem, error) {
+	var items []domain.ChecklistItem
+	err := r.DB.WithContext(ctx).Where("audit_id = ?", auditID).
+		Order("standard, clause_number").
+		Find(&items).Error
+	return items, err
 }
 
-func (r *PostgresRepository) GetAuditByID(auditID string) (*domain.Audit, error) {
-	var audit domain.Audit
-	if err := r.DB.First(&audit, "id = ?", auditID).Error; err != nil {
+func (r *PostgresRepository) FindChecklistItem(ctx context.Context, auditID, itemID string) (*domain.ChecklistItem, error) {
+	var item domain.ChecklistItem
+	if err := r.DB.WithContext(ctx).Where("id = ? AND audit_id = ?", itemID, auditID).First(&item).Error; err != nil {
 		return nil, err
 	}
-	return &audit, nil
+	return &item, nil
 }
 
-func (r *PostgresRepository) FindAuditAssignment(auditID, userID string) (*domain.AuditAssignment, error) {
-	var assignment domain.AuditAssignment
-	err := r.DB.Where("audit_id = ? AND user_id = ?", auditID, userID).First(&assignment).Error
-	return &assignment, err
+func (r *PostgresRepository) UpdateChecklistItem(ctx context.Context, item *domain.ChecklistItem) error {
+	return r.DB.WithContext(ctx).Save(item).Error
+}
+
+// --- Temporary links ---
+
+func (r *PostgresRepository) CreateTemporaryLink(ctx context.Context, link *domain.TemporaryLink) error {
+	return r.DB.WithContext(ctx).Create(link).Error
+}
+
+func (r *PostgresRepository) FindTemporaryLink(ctx context.Context, linkID string) (*domain.TemporaryLink, error) {
+	var link domain.TemporaryLink
+	if err := r.DB.WithContext(ctx).First(&link, "id = ?", linkID).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *PostgresRepository) FindTemporaryLinkByHash(ctx context.Context, tokenHash string) (*domain.TemporaryLink, error) {
+	var link domain.TemporaryLink
+	if err := r.DB.WithContext(ctx).First(&link, "token_hash = ?", tokenHash).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *PostgresRepository) ListTemporaryLinks(ctx context.Context, auditID string) ([]domain.TemporaryLink, error) {
+	var links []domain.TemporaryLink
+	err := r.DB.WithContext(ctx).Where("audit_id = ?", auditID).Order("created_at DESC").Find(&links).Error
+	return links, err
+}
+
+func (r *PostgresRepository) RevokeTemporaryLink(ctx context.Context, auditID, linkID string) error {
+	return r.DB.WithContext(ctx).Model(&domain.TemporaryLink{}).
+		Where("id = ? AND audit_id = ? AND revoked_at IS NULL", linkID, auditID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *PostgresRepository) RegisterTemporaryLinkUse(ctx context.Context, linkID string) error {
+	now := time.Now()
+	res := r.DB.WithContext(ctx).Model(&domain.TemporaryLink{}).
+		Where("id = ? AND revoked_at IS NULL AND expires_at > ? AND (max_uses = 0 OR use_count < max_uses)", linkID, now).
+		Updates(map[string]interface{}{
+			"use_count":    gorm.Expr("use_count + 1"),
+			"last_used_at": now,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: temporary link is no longer usable", domain.ErrForbidden)
+	}
+	return nil
+}
+
+// --- Lifecycle ---
+
+func (r *PostgresRepository) UpdateAuditStatus(ctx context.Context, change *domain.AuditStatusChange) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Update condicionado al estado previo: evita carreras entre dos transiciones simultáneas
+		res := tx.Model(&domain.Audit{}).
+			Where("id = ? AND status = ?", change.AuditID, change.FromStatus).
+			Update("status", change.ToStatus)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w: audit %s is no longer in status %s", domain.ErrConflict, change.AuditID, change.FromStatus)
+		}
+		return tx.Create(change).Error
+	})
+}
+
+func (r *PostgresRepository) ListAuditStatusHistory(ctx context.Context, auditID string) ([]domain.AuditStatusChange, error) {
+	var history []domain.AuditStatusChange
+	err := r.DB.WithContext(ctx).Where("audit_id = ?", auditID).Order("created_at").Find(&history).Error
+	return history, err
+}
+
+// --- PlanningRepository Implementation ---
+
+func (r *PostgresRepository) UpdateAuditSchedule(ctx context.Context, auditID string, plannedStart, plannedEnd *time.Time) error {
+	return r.DB.WithContext(ctx).Model(&domain.Audit{}).Where("id = ?", auditID).
+		Updates(map[string]interface{}{"planned_start": plannedStart, "planned_end": plannedEnd}).Error
+}
+
+func (r *PostgresRepository) CreateAuditSession(ctx context.Context, session *domain.AuditSession) error {
+	return r.DB.WithContext(ctx).Create(session).Error
+}
+
+func (r *PostgresRepository) ListAuditSessions(ctx context.Context, auditID string) ([]domain.AuditSession, error) {
+	var sessions []domain.AuditSession
+	err := r.DB.WithContext(ctx).Where("audit_id = ?", auditID).Order("starts_at").Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *PostgresRepository) FindAuditSession(ctx context.Context, auditID, sessionID string) (*domain.AuditSession, error) {
+	var session domain.AuditSession
+	if err := r.DB.WithContext(ctx).Where("id = ? AND audit_id = ?", sessionID, auditID).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *PostgresRepository) UpdateAuditSession(ctx context.Context, session *domain.AuditSession) error {
+	return r.DB.WithContext(ctx).Save(session).Error
+}
+
+func (r *PostgresRepository) DeleteAuditSession(ctx context.Context, auditID, sessionID string) error {
+	return r.DB.WithContext(ctx).Where("id = ? AND audit_id = ?", sessionID, auditID).Delete(&domain.AuditSession{}).Error
+}
+
+func (r *PostgresRepository) FindOverlappingSessions(ctx context.Context, auditorID string, start, end time.Time, excludeID string) ([]domain.AuditSession, error) {
+	var sessions []domain.AuditSession
+	err := r.DB.WithContext(ctx).Joins("JOIN audits ON audits.id = audit_sessions.audit_id").
+		Where("audit_sessions.auditor_id = ? AND audit_sessions.starts_at < ? AND audit_sessions.ends_at > ?", auditorID, end, start).
+		Where("audit_sessions.id <> ? AND audits.status <> ?", excludeID, domain.AuditFinalizada).
+		Order("audit_sessions.starts_at").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *PostgresRepository) ListAuditorSessions(ctx context.Context, auditorID string) ([]domain.AuditSession, error) {
+	var sessions []domain.AuditSession
+	err := r.DB.WithContext(ctx).Where("auditor_id = ?", auditorID).Order("starts_at").Find(&sessions).Error
+	return sessions, err
+}
+
+// --- CalendarRepository Implementation ---
+
+func (r *PostgresRepository) SaveCalendarFeed(ctx context.Context, feed *domain.CalendarFeed) error {
+	return r.DB.WithContext(ctx).Save(feed).Error
+}
+
+func (r *PostgresRepository) FindCalendarFeedByHash(ctx context.Context, tokenHash string) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	if err := r.DB.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&feed).Error; err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (r *PostgresRepository) TouchCalendarFeed(ctx context.Context, userID string, fetchedAt time.Time) error {
+	return r.DB.WithContext(ctx).Model(&domain.CalendarFeed{}).Where("user_id = ?", userID).Update("last_fetched_at", fetchedAt).Error
+}
+
+func (r *PostgresRepository) DeleteCalendarFeed(ctx context.Context, userID string) error {
+	return r.DB.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.CalendarFeed{}).Error
+}
+
+// --- ProgrammeRepository Implementation ---
+
+func (r *PostgresRepository) CreateProgramme(ctx context.Context, programme *domain.AuditProgramme) error {
+	return r.DB.WithContext(ctx).Create(programme).Error
+}
+
+func (r *PostgresRepository) FindProgramme(ctx context.Context, orgID, programmeID string) (*domain.AuditProgramme, error) {
+	var programme domain.AuditProgramme
+	if err := r.DB.WithContext(ctx).Where("id = ? AND organization_id = ?", programmeID, orgID).First(&programme).Error; err != nil {
+		return nil, err
+	}
+	return &programme, nil
+}
+
+func (r *PostgresRepository) ListProgrammes(ctx context.Context, orgID string) ([]domain.AuditProgramme, error) {
+	var programmes []domain.AuditProgramme
+	err := r.DB.WithContext(ctx).Where("organization_id = ?", orgID).Order("start_year DESC, created_at").Find(&programmes).Error
+	return programmes, err
+}
+
+func (r *PostgresRepository) UpdateProgramme(ctx context.Context, programme *domain.AuditProgramme) error {
+	return r.DB.WithContext(ctx).Save(programme).Error
+}
+
+func (r *PostgresRepository) CreateProgrammeEntry(ctx context.Context, entry *domain.ProgrammeEntry) error {
+	return r.DB.WithContext(ctx).Create(entry).Error
+}
+
+func (r *PostgresRepository) FindProgrammeEntry(ctx context.Context, programmeID, entryID string) (*domain.ProgrammeEntry, error) {
+	var entry domain.ProgrammeEntry
+	if err := r.DB.WithContext(ctx).Where("id = ? AND programme_id = ?", entryID, programmeID).First(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *PostgresRepository) ListProgrammeEntries(ctx context.Context, programmeID string) ([]domain.ProgrammeEntry, error) {
+	var entries []domain.ProgrammeEntry
+	err := r.DB.WithContext(ctx).Where("programme_id = ?", programmeID).Order("period, created_at").Find(&entries).Error
+	return entries, err
+}
+
+func (r *PostgresRepository) SwapProgrammeEntryAudit(ctx context.Context, programmeID, entryID, current, next string) error {
+	res := r.DB.WithContext(ctx).Model(&domain.ProgrammeEntry{}).
+		Where("id = ? AND programme_id = ? AND audit_id = ?", entryID, programmeID, current).
+		Update("audit_id", next)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("%w: programme entry audit changed concurrently", domain.ErrConflict)
+	}
+	return nil
+}
+
+func (r *PostgresRepository) DeleteProgrammeEntry(ctx context.Context, programmeID, entryID string) error {
+	return r.DB.WithContext(ctx).Where("id = ? AND programme_id = ?", entryID, programmeID).Delete(&domain.ProgrammeEntry{}).Error
+}
+
+// --- StandardRepository Implementation ---
+
+func (r *PostgresRepository) CreateStandard(ctx context.Context, standard *domain.Standard, clauses []domain.Clause) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(standard).Error; err != nil {
+			return err
+		}
+		for i := range clauses {
+			clauses[i].StandardID = standard.ID
+		}
+		if len(clauses) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(clauses, 200).Error
+	})
+}
+
+// visibleStandards: catálogo global + normas privadas de la organización
+func (r *PostgresRepository) visibleStandards(ctx context.Context, orgID string) *gorm.DB {
+	return r.DB.WithContext(ctx).Where("organization_id = '' OR organization_id = ?", orgID)
+}
+
+func (r *PostgresRepository) ListStandards(ctx context.Context, orgID string) ([]domain.Standard, error) {
+	var standards []domain.Standard
+	err := r.visibleStandards(ctx, orgID).Order("code, version").Find(&standards).Error
+	return standards, err
+}
+
+func (r *PostgresRepository) FindStandard(ctx context.Context, orgID, standardID string) (*domain.Standard, error) {
+	var standard domain.Standard
+	if err := r.visibleStandards(ctx, orgID).Where("id = ?", standardID).First(&standard).Error; err != nil {
+		return nil, err
+	}
+	return &standard, nil
+}
+
+func (r *PostgresRepository) ListClauses(ctx context.Context, standardID string) ([]domain.Clause, error) {
+	var clauses []domain.Clause
+	err := r.DB.WithContext(ctx).Where("standard_id = ?", standardID).Order("position").Find(&clauses).Error
+	return clauses, err
+}
+
+func (r *PostgresRepository) FindClause(ctx context.Context, clauseID string) (*domain.Clause, error) {
+	var clause domain.Clause
+	if err := r.DB.WithContext(ctx).Where("id = ?", clauseID).First(&clause).Error; err != nil {
+		return nil, err
+	}
+	return &clause, nil
+}
+
+func (r *PostgresRepository) DeleteStandard(ctx context.Context, orgID, standardID string) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? AND organization_id = ? AND organization_id <> ''", standardID, orgID).Delete(&domain.Standard{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+		return tx.Where("standard_id = ?", standardID).Delete(&domain.Clause{}).Error
+	})
+}
+
+// --- ChecklistTemplateRepository Implementation ---
+
+func (r *PostgresRepository) CreateChecklistTemplate(ctx context.Context, tpl *domain.ChecklistTemplate, items []domain.ChecklistTemplateItem) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// La restricción única (family_id, version) rechaza dos versiones simultáneas
+		if err := tx.Create(tpl).Error; err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].TemplateID = tpl.ID
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(items, 200).Error
+	})
+}
+
+func (r *PostgresRepository) FindChecklistTemplate(ctx context.Context, orgID, templateID string) (*domain.ChecklistTemplate, error) {
+	var tpl domain.ChecklistTemplate
+	if err := r.DB.WithContext(ctx).Where("id = ? AND organization_id = ?", templateID, orgID).First(&tpl).Error; err != nil {
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+func (r *PostgresRepository) ListChecklistTemplates(ctx context.Context, orgID string) ([]domain.ChecklistTemplate, error) {
+	var templates []domain.ChecklistTemplate
+	latest := r.DB.WithContext(ctx).Model(&domain.ChecklistTemplate{}).
+		Select("family_id, MAX(version) AS version").
+		Where("organization_id = ?", orgID).
+		Group("family_id")
+	err := r.DB.WithContext(ctx).Joins("JOIN (?) AS latest ON latest.family_id = checklist_templates.family_id AND latest.version = checklist_templates.version", latest).
+		Where("checklist_templates.organization_id = ?", orgID).
+		Order("checklist_templates.name").
+		Find(&templates).Error
+	return templates, err
+}
+
+func (r *PostgresRepository) ListChecklistTemplateVersions(ctx context.Context, orgID, familyID string) ([]domain.ChecklistTemplate, error) {
+	var templates []domain.ChecklistTemplate
+	err := r.DB.WithContext(ctx).Where("organization_id = ? AND family_id = ?", orgID, familyID).Order("version DESC").Find(&templates).Error
+	return templates, err
+}
+
+func (r *PostgresRepository) ListChecklistTemplateItems(ctx context.Context, templateID string) ([]domain.ChecklistTemplateItem, error) {
+	var items []domain.ChecklistTemplateItem
+	err := r.DB.WithContext(ctx).Where("template_id = ?", templateID).Order("position").Find(&items).Error
+	return items, err
+}
+
+// --- FindingRepository Implementation ---
+
+func (r *PostgresRepository) CreateFinding(ctx context.Context, finding *domain.Finding) error {
+	return r.DB.WithContext(ctx).Create(finding).Error
+}
+
+func (r *PostgresRepository) ListFindingsByAudit(ctx context.Context, auditID string) ([]domain.Finding, error) {
+	var findings []domain.Finding
+	err := r.DB.WithContext(ctx).Where("audit_id = ?", auditID).Order("created_at").Find(&findings).Error
+	return findings, err
+}
+
+func (r *PostgresRepository) FindFinding(ctx context.Context, auditID, findingID string) (*domain.Finding, error) {
+	var finding domain.Finding
+	if err := r.DB.WithContext(ctx).Where("id = ? AND audit_id = ?", findingID, auditID).First(&finding).Error; err != nil {
+		return nil, err
+	}
+	return &finding, nil
+}
+
+func (r *PostgresRepository) UpdateFinding(ctx context.Context, finding *domain.Finding) error {
+	return r.DB.WithContext(ctx).Save(finding).Error
+}
+
+func (r *PostgresRepository) DeleteFinding(ctx context.Context, auditID, findingID string) error {
+	return r.DB.WithContext(ctx).Where("id = ? AND audit_id = ?", findingID, auditID).Delete(&domain.Finding{}).Error
+}
+
+func (r *PostgresRepository) ListFindingsForAuditee(ctx context.Context, auditeeOrgID, auditID string) ([]domain.Finding, error) {
+	var findings []domain.Finding
+	err := r.DB.WithContext(ctx).Joins("JOIN audits ON audits.id = findings.audit_id").
+		Where("findings.audit_id = ? AND audits.auditee_org_id = ?", auditID, auditeeOrgID).
+		Order("findings.created_at").
+		Find(&findings).Error
+	return findings, err
+}
+
+// --- ClientRepository Implementation ---
+
+func (r *PostgresRepository) CreateClient(ctx context.Context, client *domain.Client, org *domain.Organization) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return err
+		}
+		client.ClientOrgID = org.ID
+		return tx.Create(client).Error
+	})
+}
+
+// clientsQuery incluye el nombre de la organización cliente (Client.Name es de solo lectura)
+func (r *PostgresRepository) clientsQuery(ctx context.Context, consultoraID string) *gorm.DB {
+	return r.DB.WithContext(ctx).Model(&domain.Client{}).
+		Select("clients.*, organizations.name AS name").
+		Joins("JOIN organizations ON organizations.id = clients.client_org_id").
+		Where("clients.consultora_id = ?", consultoraID)
+}
+
+func (r *PostgresRepository) FindClient(ctx context.Context, consultoraID, clientID string) (*domain.Client, error) {
+	var client domain.Client
+	if err := r.clientsQuery(ctx, consultoraID).Where("clients.id = ?", clientID).First(&client).Error; err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *PostgresRepository) ListClients(ctx context.Context, consultoraID string) ([]domain.Client, error) {
+	var clients []domain.Client
+	err := r.clientsQuery(ctx, consultoraID).Order("organizations.name").Find(&clients).Error
+	return clients, err
+}
+
+// UpdateClient actualiza los datos del vínculo y el nombre de la organización cliente
+func (r *PostgresRepository) UpdateClient(ctx context.Context, client *domain.Client) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.Client{}).
+			Where("id = ? AND consultora_id = ?", client.ID, client.ConsultoraID).
+			Updates(map[string]interface{}{
+				"sites":         client.Sites,
+				"contact_name":  client.ContactName,
+				"contact_email": client.ContactEmail,
+				"contact_phone": client.ContactPhone,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&domain.Organization{}).Where("id = ?", client.ClientOrgID).Update("name", client.Name).Error
+	})
+}
+
+// --- CAPARepository Implementation ---
+
+func (r *PostgresRepository) CreateCAPA(ctx context.Context, capa *domain.CorrectiveAction) error {
+	return r.DB.WithContext(ctx).Create(capa).Error
+}
+
+func (r *PostgresRepository) FindCAPA(ctx context.Context, capaID string) (*domain.CorrectiveAction, error) {
+	var capa domain.CorrectiveAction
+	if err := r.DB.WithContext(ctx).First(&capa, "id = ?", capaID).Error; err != nil {
+		return nil, err
+	}
+	return &capa, nil
+}
+
+func (r *PostgresRepository) ListCAPAsByAudit(ctx context.Context, auditID string) ([]domain.CorrectiveAction, error) {
+	var capas []domain.CorrectiveAction
+	err := r.DB.WithContext(ctx).Where("audit_id = ?", auditID).Order("due_date").Find(&capas).Error
+	return capas, err
+}
+
+func (r *PostgresRepository) ListCAPAsByOrg(ctx context.Context, orgID string) ([]domain.CorrectiveAction, error) {
+	var capas []domain.CorrectiveAction
+	err := r.DB.WithContext(ctx).Where("responsible_org_id = ?", orgID).Order("due_date").Find(&capas).Error
+	return capas, err
+}
+
+func (r *PostgresRepository) UpdateCAPA(ctx context.Context, capa *domain.CorrectiveAction) error {
+	return r.DB.WithContext(ctx).Save(capa).Error
+}
+
+// --- EvidenceRepository Implementation ---
+
+func (r *PostgresRepository) CreateEvidence(ctx context.Context, evidence *domain.Evidence) error {
+	return r.DB.WithContext(ctx).Create(evidence).Error
+}
+
+func (r *PostgresRepository) ListEvidenceByAudit(ctx context.Context, auditID string) ([]domain.Evidence, error) {
+	var evidence []domain.Evidence
+	err := r.DB.WithContext(ctx).Where("audit_id = ?", auditID).Order("created_at").Find(&evidence).Error
+	return evidence, err
+}
+
+func (r *PostgresRepository) FindEvidence(ctx context.Context, auditID, evidenceID string) (*domain.Evidence, error) {
+	var evidence domain.Evidence
+	if err := r.DB.WithContext(ctx).Where("id = ? AND audit_id = ?", evidenceID, auditID).First(&evidence).Error; err != nil {
+		return nil, err
+	}
+	return &evidence, nil
+}
+
+func (r *PostgresRepository) DeleteEvidence(ctx context.Context, auditID, evidenceID string) error {
+	return r.DB.WithContext(ctx).Where("id = ? AND audit_id = ?", evidenceID, auditID).Delete(&domain.Evidence{}).Error
+}
+
+// --- ReportTemplateRepository Implementation ---
+
+func (r *PostgresRepository) GetReportTemplate(ctx context.Context, orgID string) (*domain.ReportTemplate, error) {
+	var tpl domain.ReportTemplate
+	if err := r.DB.WithContext(ctx).First(&tpl, "organization_id = ?", orgID).Error; err != nil {
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+func (r *PostgresRepository) SaveReportTemplate(ctx context.Context, tpl *domain.ReportTemplate) error {
+	return r.DB.WithContext(ctx).Save(tpl).Error
+}
+
+// --- InvitationRepository Implementation ---
+
+func (r *PostgresRepository) CreateInvitation(ctx context.Context, inv *domain.Invitation) error {
+	return r.DB.WithContext(ctx).Create(inv).Error
+}
+
+func (r *PostgresRepository) FindInvitation(ctx context.Context, orgID, invitationID string) (*domain.Invitation, error) {
+	var inv domain.Invitation
+	if err := r.DB.WithContext(ctx).Where("id = ? AND organization_id = ?", invitationID, orgID).First(&inv).Error; err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+func (r *PostgresRepository) FindInvitationByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	var inv domain.Invitation
+	if err := r.DB.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&inv).Error; err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+func (r *PostgresRepository) ListInvitations(ctx context.Context, orgID string) ([]domain.Invitation, error) {
+	var invs []domain.Invitation
+	err := r.DB.WithContext(ctx).Where("organization_id = ?", orgID).Order("created_at DESC").Find(&invs).Error
+	return invs, err
+}
+
+func (r *PostgresRepository) UpdateInvitation(ctx context.Context, inv *domain.Invitation) error {
+	return r.DB.WithContext(ctx).Save(inv).Error
+}
+
+func (r *PostgresRepository) AcceptInvitation(ctx context.Context, inv *domain.Invitation, newUser *domain.User, userOrg *domain.UserOrganization) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Marcar como usada solo si sigue pendiente: evita aceptar dos veces el mismo token
+		res := tx.Model(&domain.Invitation{}).
+			Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", inv.ID).
+			Update("accepted_at", inv.AcceptedAt)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("%w: invitation already used", domain.ErrConflict)
+		}
+
+		if newUser != nil {
+			if err := tx.Create(newUser).Error; err != nil {
+				return err
+			}
+			userOrg.UserID = newUser.ID
+		}
+
+		// Invitado -> Activo, o alta directa si no hay membresía. Una baja (Inactivo) o un
+		// miembro ya activo no se reactivan con una invitación vieja.
+		var existing domain.UserOrganization
+		err := tx.Where("user_id = ? AND organization_id = ?", userOrg.UserID, userOrg.OrganizationID).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(userOrg).Error
+		}
+		if err != nil {
+			return err
+		}
+		if existing.Status != domain.MemberInvitado {
+			return fmt.Errorf("%w: membership is %s", domain.ErrConflict, existing.Status)
+		}
+		return tx.Model(&existing).Updates(map[string]interface{}{
+			"status":       domain.MemberActivo,
+			"role_default": userOrg.RoleDefault,
+		}).Error
+	})
+}
+
+// --- ActivityRepository Implementation ---
+
+func (r *PostgresRepository) AppendActivity(ctx context.Context, entry *domain.ActivityEntry) error {
+	return appendActivity(r.DB.WithContext(ctx), entry, func(tx *gorm.DB) error {
+		// Serializa las altas de la cadena de la organización hasta el fin de la transacción
+		return tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "activity:"+entry.OrganizationID).Error
+	})
+}
+
+// appendActivity encadena entry al último registro de su organización. lock serializa las
+// altas concurrentes con lo que ofrezca cada motor.
+func appendActivity(db *gorm.DB, entry *domain.ActivityEntry, lock func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := lock(tx); err != nil {
+			return err
+		}
+
+		var last domain.ActivityEntry
+		if err := tx.Where("organization_id = ?", entry.OrganizationID).
+			Order("seq DESC").Limit(1).Find(&last).Error; err != nil {
+			return err
+		}
+
+		// Postgres guarda microsegundos: truncar antes de calcular el hash para que coincida al releerlo
+		if entry.CreatedAt.IsZero() {
+			entry.CreatedAt = time.Now()
+		}
+		entry.CreatedAt = entry.CreatedAt.UTC().Truncate(time.Microsecond)
+		entry.Seq = last.Seq + 1
+		entry.PrevHash = last.Hash
+		entry.Hash = entry.ComputeHash()
+		return tx.Create(entry).Error
+	})
+}
+
+func (r *PostgresRepository) ListActivity(ctx context.Context, orgID string, filter domain.ActivityFilter) ([]domain.ActivityEntry, error) {
+	query := r.DB.WithContext(ctx).Where("organization_id = ?", orgID)
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if filter.BeforeSeq > 0 {
+		query = query.Where("seq < ?", filter.BeforeSeq)
+	}
+
+	var entries []domain.ActivityEntry
+	err := query.Order("seq DESC").Limit(filter.Limit).Find(&entries).Error
+	return entries, err
+}
+
+func (r *PostgresRepository) ListActivityChain(ctx context.Context, orgID string, afterSeq int64, limit int) ([]domain.ActivityEntry, error) {
+	var entries []domain.ActivityEntry
+	err := r.DB.WithContext(ctx).Where("organization_id = ? AND seq > ?", orgID, afterSeq).
+		Order("seq ASC").Limit(limit).Find(&entries).Error
+	return entries, err
+}
+
+// --- AdminRepository Implementation ---
+
+func (r *PostgresRepository) UpdateUserPassword(ctx context.Context, userID, passwordHash string) error {
+	return r.DB.WithContext(ctx).Model(&domain.User{}).Where("id = ?", userID).Update("password", passwordHash).Error
+}
+
+func (r *PostgresRepository) UpdateMemberRole(ctx context.Context, userID, orgID string, role domain.Role) error {
+	return r.DB.WithContext(ctx).Model(&domain.UserOrganization{}).
+		Where("user_id = ? AND organization_id = ?", userID, orgID).
+		Update("role_default", role).Error
+}
+
+func (r *PostgresRepository) PurgeExpiredRevokedTokens(ctx context.Context, now time.Time) (int64, error) {
+	result := r.DB.WithContext(ctx).Where("expires_at <= ?", now).Delete(&domain.RevokedToken{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *PostgresRepository) ListOrgAudits(ctx context.Context, orgID string) ([]domain.Audit, error) {
+	var audits []domain.Audit
+	err := r.DB.WithContext(ctx).Where("org_owner_id = ?", orgID).Order("created_at DESC").Find(&audits).Error
+	return audits, err
 }
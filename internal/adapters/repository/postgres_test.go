@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"os"
+	"testing"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/repository/repotest"
+)
+
+// TestPostgresContract corre contra la base de TEST_POSTGRES_DSN (ej: la de docker-compose)
+func TestPostgresContract(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN no definido")
+	}
+	repo, err := OpenPostgres(dsn, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repotest.Run(t, func(t *testing.T) repotest.Store { return repo })
+}
@@ -0,0 +1,610 @@
+// Package repotest es el contrato compartido de los repositorios de auth, organizaciones y
+// auditorías. Toda implementación (PostgresRepository, SQLiteRepository, MemoryRepository)
+// tiene que pasarlo:
+//
+//	func TestMemoryContract(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) repotest.Store { return repository.NewMemoryRepository() })
+//	}
+//
+// Las pruebas no asumen un almacén vacío (emails e IDs son únicos por prueba), así que
+// contra Postgres se puede reutilizar la misma base entre casos.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Store es lo que se verifica: los tres puertos más la baja lógica de usuarios
+type Store interface {
+	ports.AuthRepository
+	ports.OrganizationRepository
+	ports.AuditRepository
+	DeleteUser(ctx context.Context, userID string) error
+}
+
+// Run ejecuta el contrato completo; newStore se llama una vez por caso
+func Run(t *testing.T, newStore func(t *testing.T) Store) {
+	cases := []struct {
+		name string
+		fn   func(t *testing.T, s Store)
+	}{
+		{"UserWithOrg", testUserWithOrg},
+		{"UniqueEmail", testUniqueEmail},
+		{"SoftDelete", testSoftDelete},
+		{"Memberships", testMemberships},
+		{"RevokedTokens", testRevokedTokens},
+		{"Sessions", testSessions},
+		{"Staff", testStaff},
+		{"Audits", testAudits},
+		{"AuditsByUser", testAuditsByUser},
+		{"AuditeeViews", testAuditeeViews},
+		{"Checklist", testChecklist},
+		{"TemporaryLinks", testTemporaryLinks},
+		{"Lifecycle", testLifecycle},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.fn(t, newStore(t))
+		})
+	}
+}
+
+// --- Helpers ---
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func mustFail(t *testing.T, err error, what string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("%s: expected an error", what)
+	}
+}
+
+func mustBeNotFound(t *testing.T, err error, what string) {
+	t.Helper()
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("%s: expected gorm.ErrRecordNotFound, got %v", what, err)
+	}
+}
+
+func uniqueEmail() string {
+	return uuid.New().String() + "@contract.test"
+}
+
+// register da de alta un usuario con su organización, como AuthService.Register
+func register(t *testing.T, s Store) (*domain.User, *domain.Organization) {
+	ctx := context.Background()
+	t.Helper()
+	user := &domain.User{Email: uniqueEmail(), Password: "hash"}
+	org := &domain.Organization{Name: "Org " + user.Email}
+	userOrg := &domain.UserOrganization{
+		RoleDefault: domain.RoleConsultora,
+		Status:      domain.MemberActivo,
+		JoinedAt:    time.Now(),
+	}
+	must(t, s.CreateUserWithOrg(ctx, user, org, userOrg))
+	return user, org
+}
+
+func newAudit(t *testing.T, s Store, orgID, auditeeOrgID string) *domain.Audit {
+	ctx := context.Background()
+	t.Helper()
+	audit := &domain.Audit{Title: "Auditoría " + uuid.New().String(), OrgOwnerID: orgID, AuditeeOrgID: auditeeOrgID}
+	must(t, s.CreateAudit(ctx, audit))
+	return audit
+}
+
+// --- AuthRepository ---
+
+func testUserWithOrg(t *testing.T, s Store) {
+	ctx := context.Background()
+	user, org := register(t, s)
+	if user.ID == "" || org.ID == "" {
+		t.Fatal("CreateUserWithOrg must assign IDs")
+	}
+
+	found, err := s.FindUserByEmail(ctx, user.Email)
+	must(t, err)
+	if found.ID != user.ID {
+		t.Fatalf("FindUserByEmail returned %s, want %s", found.ID, user.ID)
+	}
+	byID, err := s.FindUserByID(ctx, user.ID)
+	must(t, err)
+	if byID.Email != user.Email {
+		t.Fatalf("FindUserByID returned %s, want %s", byID.Email, user.Email)
+	}
+
+	stored, err := s.FindOrganization(ctx, org.ID)
+	must(t, err)
+	if stored.Name != org.Name {
+		t.Fatalf("organization name = %q, want %q", stored.Name, org.Name)
+	}
+
+	member, err := s.FindMembership(ctx, user.ID, org.ID)
+	must(t, err)
+	if member.RoleDefault != domain.RoleConsultora || member.Status != domain.MemberActivo {
+		t.Fatalf("unexpected membership %+v", member)
+	}
+
+	_, err = s.FindUserByEmail(ctx, uniqueEmail())
+	mustBeNotFound(t, err, "FindUserByEmail of unknown email")
+	_, err = s.FindUserByID(ctx, uuid.New().String())
+	mustBeNotFound(t, err, "FindUserByID of unknown user")
+}
+
+func testUniqueEmail(t *testing.T, s Store) {
+	ctx := context.Background()
+	user, _ := register(t, s)
+
+	dup := &domain.User{Email: user.Email, Password: "hash"}
+	org := &domain.Organization{Name: "Duplicada"}
+	err := s.CreateUserWithOrg(ctx, dup, org, &domain.UserOrganization{RoleDefault: domain.RoleConsultora, JoinedAt: time.Now()})
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("duplicate email: expected gorm.ErrDuplicatedKey, got %v", err)
+	}
+	// La alta es atómica: la organización no queda creada
+	if org.ID != "" {
+		_, err := s.FindOrganization(ctx, org.ID)
+		mustBeNotFound(t, err, "organization of a failed registration")
+	}
+
+	_, owner := register(t, s)
+	err = s.CreateUserAndAddToOrg(ctx, &domain.User{Email: user.Email, Password: "hash"},
+		&domain.UserOrganization{OrganizationID: owner.ID, RoleDefault: domain.RoleAuxiliar, JoinedAt: time.Now()})
+	if !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("CreateUserAndAddToOrg with duplicate email: expected gorm.ErrDuplicatedKey, got %v", err)
+	}
+}
+
+func testSoftDelete(t *testing.T, s Store) {
+	ctx := context.Background()
+	user, _ := register(t, s)
+	must(t, s.DeleteUser(ctx, user.ID))
+
+	_, err := s.FindUserByEmail(ctx, user.Email)
+	mustBeNotFound(t, err, "FindUserByEmail of a deleted user")
+	_, err = s.FindUserByID(ctx, user.ID)
+	mustBeNotFound(t, err, "FindUserByID of a deleted user")
+
+	// El email sigue reservado
+	err = s.CreateUserWithOrg(ctx, &domain.User{Email: user.Email, Password: "hash"}, &domain.Organization{Name: "Otra"},
+		&domain.UserOrganization{RoleDefault: domain.RoleConsultora, JoinedAt: time.Now()})
+	mustFail(t, err, "reusing the email of a deleted user")
+}
+
+func testMemberships(t *testing.T, s Store) {
+	ctx := context.Background()
+	user, first := register(t, s)
+	firstJoined, err := s.FindMembership(ctx, user.ID, first.ID)
+	must(t, err)
+
+	_, second := register(t, s)
+	_, third := register(t, s)
+	// Status vacío toma el default de la columna (Activo)
+	must(t, s.AddUserToOrg(ctx, &domain.UserOrganization{
+		UserID: user.ID, OrganizationID: second.ID, RoleDefault: domain.RoleAuditorLider,
+		JoinedAt: firstJoined.JoinedAt.Add(time.Hour),
+	}))
+	must(t, s.AddUserToOrg(ctx, &domain.UserOrganization{
+		UserID: user.ID, OrganizationID: third.ID, RoleDefault: domain.RoleAuxiliar,
+		Status: domain.MemberInvitado, JoinedAt: firstJoined.JoinedAt.Add(2 * time.Hour),
+	}))
+
+	member, err := s.FindMembership(ctx, user.ID, second.ID)
+	must(t, err)
+	if member.Status != domain.MemberActivo {
+		t.Fatalf("default membership status = %q, want %q", member.Status, domain.MemberActivo)
+	}
+
+	orgs, err := s.ListUserOrganizations(ctx, user.ID)
+	must(t, err)
+	if len(orgs) != 3 || orgs[0].OrganizationID != first.ID || orgs[1].OrganizationID != second.ID || orgs[2].OrganizationID != third.ID {
+		t.Fatalf("ListUserOrganizations must be ordered by joined_at, got %+v", orgs)
+	}
+	if orgs[1].Name != second.Name || orgs[2].Status != domain.MemberInvitado {
+		t.Fatalf("ListUserOrganizations must join the organization name and keep the status, got %+v", orgs[1:])
+	}
+
+	// La organización principal es la membresía activa más antigua
+	must(t, s.UpdateUserStatus(ctx, user.ID, first.ID, domain.MemberInactivo))
+	primary, err := s.GetUserPrimaryOrg(ctx, user.ID)
+	must(t, err)
+	if primary.OrganizationID != second.ID {
+		t.Fatalf("GetUserPrimaryOrg = %s, want %s", primary.OrganizationID, second.ID)
+	}
+
+	_, err = s.FindMembership(ctx, user.ID, uuid.New().String())
+	mustBeNotFound(t, err, "FindMembership outside the organization")
+}
+
+func testRevokedTokens(t *testing.T, s Store) {
+	ctx := context.Background()
+	jti := uuid.New().String()
+	revoked, err := s.IsTokenRevoked(ctx, jti)
+	must(t, err)
+	if revoked {
+		t.Fatal("a new jti must not be revoked")
+	}
+	must(t, s.RevokeToken(ctx, jti, time.Now().Add(time.Hour)))
+	// Revocar dos veces no es un error (logout desde dos pestañas)
+	must(t, s.RevokeToken(ctx, jti, time.Now().Add(time.Hour)))
+	revoked, err = s.IsTokenRevoked(ctx, jti)
+	must(t, err)
+	if !revoked {
+		t.Fatal("IsTokenRevoked must report a revoked jti")
+	}
+}
+
+func testSessions(t *testing.T, s Store) {
+	ctx := context.Background()
+	user, org := register(t, s)
+	now := time.Now()
+	newSession := func(lastUsed, expires time.Time) *domain.Session {
+		session := &domain.Session{
+			ID:               uuid.New().String(),
+			UserID:           user.ID,
+			OrganizationID:   org.ID,
+			Role:             domain.RoleConsultora,
+			RefreshTokenHash: uuid.New().String(),
+			LastUsedAt:       lastUsed,
+			ExpiresAt:        expires,
+		}
+		must(t, s.CreateSession(ctx, session))
+		return session
+	}
+	older := newSession(now.Add(-time.Hour), now.Add(time.Hour))
+	newer := newSession(now, now.Add(time.Hour))
+	newSession(now, now.Add(-time.Minute)) // Vencida
+	revoked := newSession(now, now.Add(time.Hour))
+	must(t, s.RevokeSession(ctx, revoked.ID))
+	must(t, s.RevokeSession(ctx, revoked.ID))
+
+	dup := *newer
+	dup.ID = uuid.New().String()
+	mustFail(t, s.CreateSession(ctx, &dup), "two sessions with the same refresh token hash")
+
+	active, err := s.ListActiveSessions(ctx, user.ID)
+	must(t, err)
+	if len(active) != 2 || active[0].ID != newer.ID || active[1].ID != older.ID {
+		t.Fatalf("ListActiveSessions must skip revoked/expired sessions and order by last use, got %d sessions", len(active))
+	}
+
+	must(t, s.RotateSession(ctx, older.ID, older.RefreshTokenHash, "rotated-"+older.ID, now.Add(2*time.Hour)))
+	stored, err := s.FindSession(ctx, older.ID)
+	must(t, err)
+	if stored.RefreshTokenHash != "rotated-"+older.ID {
+		t.Fatal("RotateSession must replace the refresh token hash")
+	}
+	// Reutilizar el hash anterior es un conflicto
+	if err := s.RotateSession(ctx, older.ID, older.RefreshTokenHash, "again", now.Add(2*time.Hour)); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("rotating with a stale hash: expected domain.ErrConflict, got %v", err)
+	}
+	if err := s.RotateSession(ctx, revoked.ID, revoked.RefreshTokenHash, "again", now.Add(2*time.Hour)); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("rotating a revoked session: expected domain.ErrConflict, got %v", err)
+	}
+
+	_, err = s.FindSession(ctx, uuid.New().String())
+	mustBeNotFound(t, err, "FindSession of unknown session")
+}
+
+// --- OrganizationRepository ---
+
+func testStaff(t *testing.T, s Store) {
+	ctx := context.Background()
+	owner, org := register(t, s)
+
+	staff := &domain.User{Email: uniqueEmail(), Password: "hash"}
+	must(t, s.CreateUserAndAddToOrg(ctx, staff, &domain.UserOrganization{
+		OrganizationID: org.ID, RoleDefault: domain.RoleAuditorInterno, Status: domain.MemberInvitado, JoinedAt: time.Now(),
+	}))
+	if staff.ID == "" {
+		t.Fatal("CreateUserAndAddToOrg must assign the user ID")
+	}
+
+	err := s.AddUserToOrg(ctx, &domain.UserOrganization{UserID: owner.ID, OrganizationID: org.ID, RoleDefault: domain.RoleAuxiliar, JoinedAt: time.Now()})
+	mustFail(t, err, "adding the same user twice to an organization")
+
+	members, err := s.ListOrgStaff(ctx, org.ID)
+	must(t, err)
+	if len(members) != 2 {
+		t.Fatalf("ListOrgStaff returned %d members, want 2", len(members))
+	}
+
+	must(t, s.UpdateUserStatus(ctx, staff.ID, org.ID, domain.MemberActivo))
+	member, err := s.FindUserOrg(ctx, staff.ID, org.ID)
+	must(t, err)
+	if member.Status != domain.MemberActivo || member.RoleDefault != domain.RoleAuditorInterno {
+		t.Fatalf("unexpected membership after UpdateUserStatus: %+v", member)
+	}
+	// Actualizar una membresía inexistente no falla (UPDATE sin filas)
+	must(t, s.UpdateUserStatus(ctx, uuid.New().String(), org.ID, domain.MemberInactivo))
+
+	_, err = s.FindOrganization(ctx, uuid.New().String())
+	mustBeNotFound(t, err, "FindOrganization of unknown organization")
+}
+
+// --- AuditRepository ---
+
+func testAudits(t *testing.T, s Store) {
+	ctx := context.Background()
+	user, org := register(t, s)
+	audit := newAudit(t, s, org.ID, "")
+	if audit.ID == "" || audit.Status != domain.AuditPlanificada {
+		t.Fatalf("CreateAudit must assign the ID and default status, got %+v", audit)
+	}
+
+	// Los campos con default no se pueden insertar en su valor cero: IsActive=false queda en true
+	assignment := &domain.AuditAssignment{AuditID: audit.ID, UserID: user.ID, RoleInAudit: domain.RoleAuditorLider}
+	must(t, s.AssignUserToAudit(ctx, assignment))
+	stored, err := s.FindAuditAssignment(ctx, audit.ID, user.ID)
+	must(t, err)
+	if !stored.IsActive || stored.AcceptanceStatus != domain.AcceptPendiente {
+		t.Fatalf("assignment defaults not applied: %+v", stored)
+	}
+	mustFail(t, s.AssignUserToAudit(ctx, &domain.AuditAssignment{AuditID: audit.ID, UserID: user.ID, RoleInAudit: domain.RoleAuxiliar}),
+		"assigning the same user twice")
+
+	stored.AcceptanceStatus = domain.AcceptRechazado
+	stored.RejectionReason = "Sin disponibilidad"
+	stored.IsActive = false
+	must(t, s.UpdateAuditAssignment(ctx, stored))
+	team, err := s.ListAuditAssignments(ctx, audit.ID)
+	must(t, err)
+	if len(team) != 1 || team[0].IsActive || team[0].RejectionReason != "Sin disponibilidad" {
+		t.Fatalf("UpdateAuditAssignment must save every field, got %+v", team)
+	}
+
+	// Volver a asignar a quien tiene una asignación inactiva la reactiva en lugar de chocar con la clave
+	must(t, s.AssignUserToAudit(ctx, &domain.AuditAssignment{AuditID: audit.ID, UserID: user.ID, RoleInAudit: domain.RoleAuxiliar}))
+	stored, err = s.FindAuditAssignment(ctx, audit.ID, user.ID)
+	must(t, err)
+	if !stored.IsActive || stored.RoleInAudit != domain.RoleAuxiliar || stored.AcceptanceStatus != domain.AcceptPendiente || stored.RejectionReason != "" {
+		t.Fatalf("inactive assignment not reactivated: %+v", stored)
+	}
+	if err := s.AssignUserToAudit(ctx, &domain.AuditAssignment{AuditID: audit.ID, UserID: user.ID, RoleInAudit: domain.RoleAuxiliar}); !errors.Is(err, gorm.ErrDuplicatedKey) {
+		t.Fatalf("assigning an active user twice: got %v, want gorm.ErrDuplicatedKey", err)
+	}
+
+	// ReplaceAuditAssignment: solo sobre una asignación rechazada y activa, todo o nada
+	stored.AcceptanceStatus = domain.AcceptRechazado
+	must(t, s.UpdateAuditAssignment(ctx, stored))
+	other, _ := register(t, s)
+	busy := &domain.AuditAssignment{AuditID: audit.ID, UserID: other.ID, RoleInAudit: domain.RoleObservador}
+	must(t, s.AssignUserToAudit(ctx, busy))
+	mustFail(t, s.ReplaceAuditAssignment(ctx, stored, &domain.AuditAssignment{AuditID: audit.ID, UserID: other.ID, RoleInAudit: domain.RoleAuxiliar}),
+		"replacing with an already assigned user")
+	if kept, err := s.FindAuditAssignment(ctx, audit.ID, user.ID); err != nil || !kept.IsActive {
+		t.Fatalf("a failed ReplaceAuditAssignment must keep the rejected assignment: %+v, %v", kept, err)
+	}
+	must(t, s.UpdateAuditAssignment(ctx, &domain.AuditAssignment{AuditID: audit.ID, UserID: other.ID, RoleInAudit: domain.RoleObservador, AcceptanceStatus: domain.AcceptRechazado, IsActive: false}))
+	replacement := &domain.AuditAssignment{AuditID: audit.ID, UserID: other.ID, RoleInAudit: domain.RoleAuxiliar}
+	must(t, s.ReplaceAuditAssignment(ctx, stored, replacement))
+	if err := s.ReplaceAuditAssignment(ctx, stored, replacement); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("replacing the same slot twice: got %v, want ErrConflict", err)
+	}
+	team, err = s.ListAuditAssignments(ctx, audit.ID)
+	must(t, err)
+	for _, a := range team {
+		if a.UserID == user.ID && a.IsActive || a.UserID == other.ID && (!a.IsActive || a.RoleInAudit != domain.RoleAuxiliar) {
+			t.Fatalf("ReplaceAuditAssignment result: %+v", team)
+		}
+	}
+
+	must(t, s.UpdateAuditConclusions(ctx, audit.ID, "Sistema eficaz"))
+	reloaded, err := s.GetAuditByID(ctx, audit.ID)
+	must(t, err)
+	if reloaded.Conclusions != "Sistema eficaz" || reloaded.Title != audit.Title {
+		t.Fatalf("unexpected audit after UpdateAuditConclusions: %+v", reloaded)
+	}
+
+	_, err = s.GetAuditByID(ctx, uuid.New().String())
+	mustBeNotFound(t, err, "GetAuditByID of unknown audit")
+	_, err = s.FindAuditAssignment(ctx, audit.ID, uuid.New().String())
+	mustBeNotFound(t, err, "FindAuditAssignment of unassigned user")
+}
+
+func testAuditsByUser(t *testing.T, s Store) {
+	ctx := context.Background()
+	user, org := register(t, s)
+	pending := newAudit(t, s, org.ID, "")
+	accepted := newAudit(t, s, org.ID, "")
+	inactive := newAudit(t, s, org.ID, "")
+	newAudit(t, s, org.ID, "") // Sin asignación: no aparece
+
+	must(t, s.AssignUserToAudit(ctx, &domain.AuditAssignment{AuditID: pending.ID, UserID: user.ID, RoleInAudit: domain.RoleAuxiliar}))
+	must(t, s.AssignUserToAudit(ctx, &domain.AuditAssignment{AuditID: accepted.ID, UserID: user.ID, RoleInAudit: domain.RoleAuditorLider, AcceptanceStatus: domain.AcceptAceptado}))
+	must(t, s.AssignUserToAudit(ctx, &domain.AuditAssignment{AuditID: inactive.ID, UserID: user.ID, RoleInAudit: domain.RoleAuxiliar}))
+	off, err := s.FindAuditAssignment(ctx, inactive.ID, user.ID)
+	must(t, err)
+	off.IsActive = false
+	must(t, s.UpdateAuditAssignment(ctx, off))
+
+	all, err := s.GetAuditsByUserID(ctx, user.ID, "")
+	must(t, err)
+	if ids := auditIDs(all); len(ids) != 2 || !ids[pending.ID] || !ids[accepted.ID] {
+		t.Fatalf("GetAuditsByUserID must return only audits with an active assignment, got %d", len(all))
+	}
+
+	onlyAccepted, err := s.GetAuditsByUserID(ctx, user.ID, domain.AcceptAceptado)
+	must(t, err)
+	if len(onlyAccepted) != 1 || onlyAccepted[0].ID != accepted.ID {
+		t.Fatalf("GetAuditsByUserID must filter by acceptance, got %d", len(onlyAccepted))
+	}
+}
+
+func auditIDs(audits []domain.Audit) map[string]bool {
+	ids := map[string]bool{}
+	for _, a := range audits {
+		ids[a.ID] = true
+	}
+	return ids
+}
+
+func testAuditeeViews(t *testing.T, s Store) {
+	ctx := context.Background()
+	_, consultora := register(t, s)
+	_, client := register(t, s)
+	_, other := register(t, s)
+
+	audit := newAudit(t, s, consultora.ID, client.ID)
+	newAudit(t, s, consultora.ID, other.ID)
+	newAudit(t, s, consultora.ID, "")
+
+	audits, err := s.ListAuditsForAuditee(ctx, client.ID)
+	must(t, err)
+	if len(audits) != 1 || audits[0].ID != audit.ID {
+		t.Fatalf("ListAuditsForAuditee must only return the client's audits, got %d", len(audits))
+	}
+	_, err = s.GetAuditForAuditee(ctx, client.ID, audit.ID)
+	must(t, err)
+	_, err = s.GetAuditForAuditee(ctx, other.ID, audit.ID)
+	mustBeNotFound(t, err, "GetAuditForAuditee from another organization")
+}
+
+func testChecklist(t *testing.T, s Store) {
+	ctx := context.Background()
+	_, org := register(t, s)
+	audit := newAudit(t, s, org.ID, "")
+	other := newAudit(t, s, org.ID, "")
+
+	items := []domain.ChecklistItem{
+		{AuditID: audit.ID, Standard: "ISO 9001:2015", ClauseNumber: "8.1", Requirement: "Planificación operacional"},
+		{AuditID: audit.ID, Standard: "ISO 14001:2015", ClauseNumber: "6.1", Requirement: "Riesgos"},
+		{AuditID: audit.ID, Standard: "ISO 9001:2015", ClauseNumber: "7.5", Requirement: "Información documentada"},
+	}
+	must(t, s.CreateChecklistItems(ctx, items))
+	must(t, s.CreateChecklistItems(ctx, nil))
+	for _, item := range items {
+		if item.ID == "" || item.Answer != domain.AnswerPendiente {
+			t.Fatalf("CreateChecklistItems must assign IDs and the default answer, got %+v", item)
+		}
+	}
+
+	list, err := s.GetChecklistByAuditID(ctx, audit.ID)
+	must(t, err)
+	if len(list) != 3 || list[0].ClauseNumber != "6.1" || list[1].ClauseNumber != "7.5" || list[2].ClauseNumber != "8.1" {
+		t.Fatalf("GetChecklistByAuditID must be ordered by standard and clause, got %+v", list)
+	}
+
+	item, err := s.FindChecklistItem(ctx, audit.ID, items[0].ID)
+	must(t, err)
+	now := time.Now()
+	item.Answer = domain.AnswerConforme
+	item.AnsweredAt = &now
+	must(t, s.UpdateChecklistItem(ctx, item))
+	reloaded, err := s.FindChecklistItem(ctx, audit.ID, item.ID)
+	must(t, err)
+	if reloaded.Answer != domain.AnswerConforme || reloaded.AnsweredAt == nil {
+		t.Fatalf("UpdateChecklistItem must save the answer, got %+v", reloaded)
+	}
+
+	_, err = s.FindChecklistItem(ctx, other.ID, item.ID)
+	mustBeNotFound(t, err, "FindChecklistItem from another audit")
+}
+
+func testTemporaryLinks(t *testing.T, s Store) {
+	ctx := context.Background()
+	user, org := register(t, s)
+	audit := newAudit(t, s, org.ID, "")
+	now := time.Now()
+
+	newLink := func(createdAt time.Time, maxUses int) *domain.TemporaryLink {
+		link := &domain.TemporaryLink{
+			AuditID:   audit.ID,
+			UserID:    user.ID,
+			TokenHash: uuid.New().String(),
+			Scopes:    string(domain.ScopeView),
+			ExpiresAt: now.Add(time.Hour),
+			MaxUses:   maxUses,
+			CreatedBy: user.ID,
+			CreatedAt: createdAt,
+		}
+		must(t, s.CreateTemporaryLink(ctx, link))
+		return link
+	}
+	older := newLink(now.Add(-time.Hour), 1)
+	newer := newLink(now, 0)
+
+	dup := *newer
+	dup.ID = ""
+	mustFail(t, s.CreateTemporaryLink(ctx, &dup), "two links with the same token hash")
+
+	byHash, err := s.FindTemporaryLinkByHash(ctx, older.TokenHash)
+	must(t, err)
+	if byHash.ID != older.ID {
+		t.Fatal("FindTemporaryLinkByHash returned another link")
+	}
+	links, err := s.ListTemporaryLinks(ctx, audit.ID)
+	must(t, err)
+	if len(links) != 2 || links[0].ID != newer.ID {
+		t.Fatalf("ListTemporaryLinks must be ordered newest first, got %d links", len(links))
+	}
+
+	// max_uses = 1: el segundo uso falla
+	must(t, s.RegisterTemporaryLinkUse(ctx, older.ID))
+	if err := s.RegisterTemporaryLinkUse(ctx, older.ID); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("using an exhausted link: expected domain.ErrForbidden, got %v", err)
+	}
+	used, err := s.FindTemporaryLink(ctx, older.ID)
+	must(t, err)
+	if used.UseCount != 1 || used.LastUsedAt == nil {
+		t.Fatalf("RegisterTemporaryLinkUse must count the use, got %+v", used)
+	}
+
+	// Revocar con otra auditoría no tiene efecto
+	must(t, s.RevokeTemporaryLink(ctx, uuid.New().String(), newer.ID))
+	still, err := s.FindTemporaryLink(ctx, newer.ID)
+	must(t, err)
+	if still.RevokedAt != nil {
+		t.Fatal("RevokeTemporaryLink must be scoped to the audit")
+	}
+	must(t, s.RevokeTemporaryLink(ctx, audit.ID, newer.ID))
+	if err := s.RegisterTemporaryLinkUse(ctx, newer.ID); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("using a revoked link: expected domain.ErrForbidden, got %v", err)
+	}
+
+	_, err = s.FindTemporaryLink(ctx, uuid.New().String())
+	mustBeNotFound(t, err, "FindTemporaryLink of unknown link")
+}
+
+func testLifecycle(t *testing.T, s Store) {
+	ctx := context.Background()
+	user, org := register(t, s)
+	audit := newAudit(t, s, org.ID, "")
+
+	start := &domain.AuditStatusChange{AuditID: audit.ID, FromStatus: domain.AuditPlanificada, ToStatus: domain.AuditEnCurso, ChangedBy: user.ID}
+	must(t, s.UpdateAuditStatus(ctx, start))
+
+	// El estado previo ya no es Planificada: la segunda transición concurrente pierde
+	stale := &domain.AuditStatusChange{AuditID: audit.ID, FromStatus: domain.AuditPlanificada, ToStatus: domain.AuditEnCurso, ChangedBy: user.ID}
+	if err := s.UpdateAuditStatus(ctx, stale); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("stale transition: expected domain.ErrConflict, got %v", err)
+	}
+
+	pause := &domain.AuditStatusChange{AuditID: audit.ID, FromStatus: domain.AuditEnCurso, ToStatus: domain.AuditPausada, ChangedBy: user.ID, Reason: "Feriado"}
+	must(t, s.UpdateAuditStatus(ctx, pause))
+
+	reloaded, err := s.GetAuditByID(ctx, audit.ID)
+	must(t, err)
+	if reloaded.Status != domain.AuditPausada {
+		t.Fatalf("audit status = %s, want %s", reloaded.Status, domain.AuditPausada)
+	}
+	history, err := s.ListAuditStatusHistory(ctx, audit.ID)
+	must(t, err)
+	if len(history) != 2 || history[0].ToStatus != domain.AuditEnCurso || history[1].Reason != "Feriado" {
+		t.Fatalf("ListAuditStatusHistory must list the applied changes in order, got %+v", history)
+	}
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// SQLiteRepository es el backend para instalaciones de un solo equipo, sin servidor de base de
+// datos. Las consultas de PostgresRepository son SQL estándar vía GORM y se reutilizan tal cual;
+// aquí solo cambia lo que depende del motor (conexión, bloqueos y triggers).
+type SQLiteRepository struct {
+	*PostgresRepository
+}
+
+func NewSQLiteDB(path string, autoMigrate bool) *SQLiteRepository {
+	repo, err := OpenSQLite(path, autoMigrate)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("Conexión a SQLite y migración exitosa")
+	return repo
+}
+
+// OpenSQLite abre (o crea) la base en path y la migra. ":memory:" sirve para pruebas.
+func OpenSQLite(path string, autoMigrate bool) (*SQLiteRepository, error) {
+	db, err := ConnectSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := prepareSchema(db, autoMigrate); err != nil {
+		if conn, err := db.DB(); err == nil {
+			conn.Close()
+		}
+		return nil, fmt.Errorf("error en la migración: %w", err)
+	}
+	return &SQLiteRepository{PostgresRepository: &PostgresRepository{DB: db}}, nil
+}
+
+// ConnectSQLite abre la base creando su directorio si falta, sin tocar el esquema
+func ConnectSQLite(path string) (*gorm.DB, error) {
+	if path != ":memory:" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("no se pudo crear el directorio de la base SQLite: %w", err)
+		}
+	}
+	conn, err := sql.Open(sqlite.DriverName, path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo abrir la base SQLite: %w", err)
+	}
+	// SQLite admite un solo escritor: una conexión serializa las transacciones
+	// (y mantiene viva una base ":memory:")
+	conn.SetMaxOpenConns(1)
+
+	db, err := gorm.Open(sqlite.Dialector{Conn: &utcConnPool{conn: conn}}, &gorm.Config{TranslateError: true})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("no se pudo abrir la base SQLite: %w", err)
+	}
+	return db, nil
+}
+
+// AppendActivity no necesita bloqueo explícito: la única conexión ya serializa las transacciones
+func (r *SQLiteRepository) AppendActivity(ctx context.Context, entry *domain.ActivityEntry) error {
+	return appendActivity(r.DB.WithContext(ctx), entry, func(tx *gorm.DB) error { return nil })
+}
+
+func sqliteAppendOnly(db *gorm.DB) error {
+	for _, event := range []string{"UPDATE", "DELETE"} {
+		if err := db.Exec(`CREATE TRIGGER IF NOT EXISTS activity_entries_append_only_` + event + `
+BEFORE ` + event + ` ON activity_entries
+BEGIN
+	SELECT RAISE(ABORT, 'activity_entries es de solo inserción');
+END`).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// utcConnPool pasa a UTC las fechas que viajan como parámetro. SQLite guarda las fechas como
+// texto con su zona y las compara como texto: mezclando zonas, el orden dejaría de ser cronológico.
+type utcConnPool struct {
+	conn gorm.ConnPool
+}
+
+func (p *utcConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.conn.PrepareContext(ctx, query)
+}
+
+func (p *utcConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return p.conn.ExecContext(ctx, query, utcArgs(args)...)
+}
+
+func (p *utcConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return p.conn.QueryContext(ctx, query, utcArgs(args)...)
+}
+
+func (p *utcConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return p.conn.QueryRowContext(ctx, query, utcArgs(args)...)
+}
+
+func (p *utcConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	beginner, ok := p.conn.(gorm.TxBeginner)
+	if !ok {
+		return nil, gorm.ErrInvalidTransaction
+	}
+	tx, err := beginner.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &utcTx{utcConnPool: utcConnPool{conn: tx}, tx: tx}, nil
+}
+
+func (p *utcConnPool) GetDBConn() (*sql.DB, error) {
+	if db, ok := p.conn.(*sql.DB); ok {
+		return db, nil
+	}
+	return nil, gorm.ErrInvalidDB
+}
+
+type utcTx struct {
+	utcConnPool
+	tx *sql.Tx
+}
+
+func (t *utcTx) Commit() error   { return t.tx.Commit() }
+func (t *utcTx) Rollback() error { return t.tx.Rollback() }
+
+func utcArgs(args []interface{}) []interface{} {
+	args = append([]interface{}(nil), args...)
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case time.Time:
+			args[i] = v.UTC()
+		case *time.Time:
+			if v != nil {
+				args[i] = v.UTC()
+			}
+		case gorm.DeletedAt:
+			if v.Valid {
+				args[i] = v.Time.UTC()
+			}
+		case sql.NullTime:
+			if v.Valid {
+				args[i] = v.Time.UTC()
+			}
+		}
+	}
+	return args
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/repository/repotest"
+)
+
+func TestSQLiteContract(t *testing.T) {
+	for _, mode := range []struct {
+		name        string
+		autoMigrate bool
+	}{
+		{"Migrations", false},
+		{"AutoMigrate", true},
+	} {
+		t.Run(mode.name, func(t *testing.T) {
+			repotest.Run(t, func(t *testing.T) repotest.Store {
+				repo, err := OpenSQLite(filepath.Join(t.TempDir(), "db.sqlite"), mode.autoMigrate)
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(func() {
+					if conn, err := repo.DB.DB(); err == nil {
+						conn.Close()
+					}
+				})
+				return repo
+			})
+		})
+	}
+}
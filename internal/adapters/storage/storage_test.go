@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+func TestLocalBlobStore(t *testing.T) {
+	store, err := NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testBlobStore(t, store)
+
+	if err := store.Put(context.Background(), "../escape", bytes.NewReader(nil), 0, "text/plain"); err == nil {
+		t.Fatal("a key outside BaseDir was accepted")
+	}
+}
+
+// TestS3BlobStore corre contra un S3 real; con el docker-compose:
+//
+//	S3_ENDPOINT=localhost:9000 S3_ACCESS_KEY=... S3_SECRET_KEY=... go test ./internal/adapters/storage
+func TestS3BlobStore(t *testing.T) {
+	endpoint := os.Getenv("S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_ENDPOINT no está definido")
+	}
+	bucket := os.Getenv("S3_BUCKET")
+	if bucket == "" {
+		bucket = "iso-evidence-test"
+	}
+	store, err := NewS3BlobStore(endpoint, os.Getenv("S3_ACCESS_KEY"), os.Getenv("S3_SECRET_KEY"), bucket, os.Getenv("S3_USE_SSL") == "true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testBlobStore(t, store)
+}
+
+// testBlobStore es el contrato común de los ports.BlobStore: ida y vuelta, borrado y clave inexistente
+func testBlobStore(t *testing.T, store ports.BlobStore) {
+	t.Helper()
+	ctx := context.Background()
+	key := fmt.Sprintf("test/%d/evidencia.txt", time.Now().UnixNano())
+	content := []byte("acta de la reunión de apertura")
+
+	if err := store.Put(ctx, key, bytes.NewReader(content), int64(len(content)), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	body, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, err := io.ReadAll(body)
+	body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("Get returned %q, want %q", got, content)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if body, err := store.Get(ctx, key); err == nil {
+		body.Close()
+		t.Fatal("Get after Delete succeeded")
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete of a missing key: %v", err)
+	}
+}
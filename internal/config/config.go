@@ -3,14 +3,52 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DBDSN     string
-	JWTSecret string
-	Port      string
+	DBDriver   string // "postgres" (por defecto) o "sqlite"
+	DBDSN      string
+	SQLitePath string
+	JWTSecret  string
+	Port       string
+
+	// Plazo de cada petición (se propaga hasta las consultas a la base); las subidas de
+	// evidencias y los informes usan UploadTimeout
+	RequestTimeout time.Duration
+	UploadTimeout  time.Duration
+
+	// Esquema: por defecto se aplican las migraciones versionadas al arrancar;
+	// DB_AUTO_MIGRATE=true usa AutoMigrate de GORM (solo desarrollo)
+	DBAutoMigrate bool
+
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	InvitationTTL   time.Duration
+	TempLinkTTL     time.Duration
+
+	// Notificaciones
+	Notifier     string // "log" (por defecto), "smtp" o "noop"
+	NotifyLocale string
+	AppBaseURL   string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUser     string
+	SMTPPassword string
+	SMTPFrom     string
+
+	// Evidencias
+	BlobDriver       string // "local" (por defecto) o "s3"
+	BlobLocalDir     string
+	S3Endpoint       string
+	S3AccessKey      string
+	S3SecretKey      string
+	S3Bucket         string
+	S3UseSSL         bool
+	EvidenceMaxBytes int64
 }
 
 func LoadConfig() *Config {
@@ -30,8 +68,67 @@ func LoadConfig() *Config {
 	}
 
 	return &Config{
-		DBDSN:     dsn,
-		JWTSecret: secret,
-		Port:      os.Getenv("PORT"),
+		DBDriver:   getEnv("DB_DRIVER", "postgres"),
+		DBDSN:      dsn,
+		SQLitePath: getEnv("SQLITE_PATH", "./data/iso-stack.db"),
+		JWTSecret:  secret,
+		Port:       os.Getenv("PORT"),
+
+		DBAutoMigrate: os.Getenv("DB_AUTO_MIGRATE") == "true",
+
+		RequestTimeout: time.Duration(getEnvPositiveInt64("REQUEST_TIMEOUT_SECONDS", 30)) * time.Second,
+		UploadTimeout:  time.Duration(getEnvPositiveInt64("UPLOAD_TIMEOUT_SECONDS", 300)) * time.Second,
+
+		AccessTokenTTL:  time.Duration(getEnvInt64("ACCESS_TOKEN_TTL_MINUTES", 15)) * time.Minute,
+		RefreshTokenTTL: time.Duration(getEnvInt64("REFRESH_TOKEN_TTL_HOURS", 720)) * time.Hour,
+		InvitationTTL:   time.Duration(getEnvInt64("INVITATION_TTL_HOURS", 72)) * time.Hour,
+		TempLinkTTL:     time.Duration(getEnvInt64("TEMP_LINK_TTL_HOURS", 168)) * time.Hour,
+
+		Notifier:     getEnv("NOTIFIER", "log"),
+		NotifyLocale: getEnv("NOTIFY_LOCALE", "es"),
+		AppBaseURL:   getEnv("APP_BASE_URL", "http://localhost:3000"),
+		SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:     getEnv("SMTP_PORT", "1025"),
+		SMTPUser:     os.Getenv("SMTP_USER"),
+		SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:     getEnv("SMTP_FROM", "no-reply@isostack.local"),
+
+		BlobDriver:       getEnv("BLOB_DRIVER", "local"),
+		BlobLocalDir:     getEnv("BLOB_LOCAL_DIR", "./data/evidence"),
+		S3Endpoint:       os.Getenv("S3_ENDPOINT"),
+		S3AccessKey:      os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:      os.Getenv("S3_SECRET_KEY"),
+		S3Bucket:         getEnv("S3_BUCKET", "iso-evidence"),
+		S3UseSSL:         os.Getenv("S3_USE_SSL") == "true",
+		EvidenceMaxBytes: getEnvInt64("EVIDENCE_MAX_BYTES", 20<<20), // 20 MB
 	}
-}
\ No newline at end of file
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt64(key string, fallback int64) int64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		log.Fatalf("FATAL: %s debe ser un entero: %v", key, err)
+	}
+	return n
+}
+
+// getEnvPositiveInt64 es getEnvInt64 para valores que no admiten cero ni negativos
+// (un plazo de 0 cancelaría todas las peticiones apenas empiezan)
+func getEnvPositiveInt64(key string, fallback int64) int64 {
+	n := getEnvInt64(key, fallback)
+	if n <= 0 {
+		log.Fatalf("FATAL: %s debe ser mayor que cero: %d", key, n)
+	}
+	return n
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "context"
+
+// RequestMeta identifica la petición que origina una operación. La completan los
+// middlewares HTTP y los servicios la leen del context.Context para la bitácora.
+type RequestMeta struct {
+	RequestID string
+	ActorID   string // Usuario autenticado (vacío en rutas públicas)
+	IP        string
+}
+
+type requestMetaKey struct{}
+
+func WithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaKey{}, meta)
+}
+
+// RequestMetaFrom devuelve los datos de la petición, o un valor vacío fuera de HTTP
+func RequestMetaFrom(ctx context.Context) RequestMeta {
+	meta, _ := ctx.Value(requestMetaKey{}).(RequestMeta)
+	return meta
+}
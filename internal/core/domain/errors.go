@@ -0,0 +1,17 @@
+package domain
+
+import "errors"
+
+// Errores de negocio compartidos entre servicios y handlers.
+// Los handlers los traducen a códigos HTTP con errors.Is.
+var (
+	ErrNotFound     = errors.New("resource not found")
+	ErrForbidden    = errors.New("operation not allowed for your role")
+	ErrNotAssigned  = errors.New("user is not assigned to this audit")
+	ErrInvalidInput = errors.New("invalid input")
+	ErrAuditClosed  = errors.New("audit is finalized")
+	ErrConflict     = errors.New("resource was modified concurrently")
+	ErrDoubleBooked = errors.New("auditor is already booked in an overlapping session")
+	ErrTooLarge     = errors.New("file exceeds the maximum allowed size")
+	ErrUnsupported  = errors.New("unsupported file type")
+)
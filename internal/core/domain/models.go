@@ -1,6 +1,10 @@
 package domain
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,8 +21,18 @@ const (
 	RoleAuditorInterno Role = "Auditor_Interno"
 	RoleAuxiliar       Role = "Auxiliar"
 	RoleObservador     Role = "Observador"
+	RoleCliente        Role = "Cliente" // Usuario de una organización auditada (ver Client)
 )
 
+// IsValid indica si el rol es uno de los definidos por el sistema
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleConsultora, RoleAuditorLider, RoleAuditorInterno, RoleAuxiliar, RoleObservador, RoleCliente:
+		return true
+	}
+	return false
+}
+
 type MemberStatus string
 
 const (
@@ -44,6 +58,101 @@ const (
 	AuditPausada     AuditStatus = "Pausada"
 )
 
+type ChecklistAnswer string
+
+const (
+	AnswerPendiente   ChecklistAnswer = "Pendiente"
+	AnswerConforme    ChecklistAnswer = "Conforme"
+	AnswerNoConforme  ChecklistAnswer = "No_Conforme"
+	AnswerObservacion ChecklistAnswer = "Observación"
+	AnswerNoAplica    ChecklistAnswer = "No_Aplica"
+)
+
+// IsValid indica si la respuesta es una de las admitidas para un ítem respondido
+func (a ChecklistAnswer) IsValid() bool {
+	switch a {
+	case AnswerConforme, AnswerNoConforme, AnswerObservacion, AnswerNoAplica:
+		return true
+	}
+	return false
+}
+
+type FindingSeverity string
+
+const (
+	SeverityMayor             FindingSeverity = "Mayor"
+	SeverityMenor             FindingSeverity = "Menor"
+	SeverityObservacion       FindingSeverity = "Observación"
+	SeverityOportunidadMejora FindingSeverity = "Oportunidad_Mejora"
+)
+
+func (s FindingSeverity) IsValid() bool {
+	switch s {
+	case SeverityMayor, SeverityMenor, SeverityObservacion, SeverityOportunidadMejora:
+		return true
+	}
+	return false
+}
+
+// LinkScope delimita qué puede hacer un participante externo a través de un enlace temporal
+type LinkScope string
+
+const (
+	ScopeView            LinkScope = "view"
+	ScopeUploadEvidence  LinkScope = "evidence:upload"
+	ScopeAnswerChecklist LinkScope = "checklist:answer"
+)
+
+func (s LinkScope) IsValid() bool {
+	switch s {
+	case ScopeView, ScopeUploadEvidence, ScopeAnswerChecklist:
+		return true
+	}
+	return false
+}
+
+type CAPAStatus string
+
+const (
+	CAPAAbierta      CAPAStatus = "Abierta"
+	CAPAPropuesta    CAPAStatus = "Propuesta"
+	CAPAAprobada     CAPAStatus = "Aprobada"
+	CAPAImplementada CAPAStatus = "Implementada"
+	CAPAVerificada   CAPAStatus = "Verificada"
+	CAPARechazada    CAPAStatus = "Rechazada"
+)
+
+// ActivityEntity es el tipo de entidad sobre la que se registra un cambio en la bitácora
+type ActivityEntity string
+
+const (
+	EntityOrganization  ActivityEntity = "organization"
+	EntityUser          ActivityEntity = "user"
+	EntityMembership    ActivityEntity = "membership"
+	EntitySession       ActivityEntity = "session"
+	EntityInvitation    ActivityEntity = "invitation"
+	EntityAudit         ActivityEntity = "audit"
+	EntityAssignment    ActivityEntity = "assignment"
+	EntityTemporaryLink ActivityEntity = "temporary_link"
+	EntityChecklistItem ActivityEntity = "checklist_item"
+	EntityFinding       ActivityEntity = "finding"
+	EntityCAPA          ActivityEntity = "corrective_action"
+)
+
+type ActivityAction string
+
+const (
+	ActivityCreate     ActivityAction = "create"
+	ActivityUpdate     ActivityAction = "update"
+	ActivityRevoke     ActivityAction = "revoke"
+	ActivityLogin      ActivityAction = "login"
+	ActivityLogout     ActivityAction = "logout"
+	ActivityAccept     ActivityAction = "accept"
+	ActivityResend     ActivityAction = "resend"
+	ActivityTransition ActivityAction = "transition"
+	ActivityDelete     ActivityAction = "delete"
+)
+
 // --- MODELOS DE BASE DE DATOS ---
 
 type Organization struct {
@@ -70,12 +179,117 @@ type UserOrganization struct {
 }
 
 type Audit struct {
-	ID         string      `gorm:"primaryKey" json:"id"`
-	Title      string      `gorm:"not null" json:"title"`
-	OrgOwnerID string      `gorm:"not null;index" json:"org_owner_id"` // La empresa que la creó
-	Status     AuditStatus `gorm:"default:'Planificada'" json:"status"`
-	CreatedAt  time.Time   `json:"created_at"`
-	UpdatedAt  time.Time   `json:"updated_at"`
+	ID                  string      `gorm:"primaryKey" json:"id"`
+	Title               string      `gorm:"not null" json:"title"`
+	OrgOwnerID          string      `gorm:"not null;index" json:"org_owner_id"`    // La empresa que la creó
+	AuditeeOrgID        string      `gorm:"index" json:"auditee_org_id,omitempty"` // Cliente auditado (Client.ClientOrgID); vacío = auditoría interna
+	ChecklistTemplateID string      `json:"checklist_template_id,omitempty"`       // Versión de plantilla con la que se precargó el checklist
+	Status              AuditStatus `gorm:"default:'Planificada'" json:"status"`
+	Conclusions         string      `json:"conclusions"` // Conclusiones del Auditor_Lider para el informe
+	// Plan de auditoría (ISO 19011 6.3.2): ventana en la que se agendan las sesiones
+	PlannedStart *time.Time `json:"planned_start,omitempty"`
+	PlannedEnd   *time.Time `json:"planned_end,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// AuditSession es una franja de la agenda del plan de auditoría: qué proceso se audita,
+// dónde, a quién se entrevista y qué auditor (AuditAssignment) la conduce
+type AuditSession struct {
+	ID        string    `gorm:"primaryKey" json:"id"`
+	AuditID   string    `gorm:"not null;index" json:"audit_id"`
+	StartsAt  time.Time `gorm:"not null;index" json:"starts_at"`
+	EndsAt    time.Time `gorm:"not null;index" json:"ends_at"`
+	Site      string    `json:"site"`                             // Ej: "Planta Rosario"
+	Process   string    `gorm:"not null" json:"process"`          // Ej: "Compras"
+	Auditee   string    `json:"auditee"`                          // Persona o puesto entrevistado
+	AuditorID string    `gorm:"not null;index" json:"auditor_id"` // UserID del AuditAssignment
+	Notes     string    `json:"notes"`
+	CreatedBy string    `gorm:"not null" json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Overlaps indica si la sesión se superpone con el intervalo [start, end)
+func (s *AuditSession) Overlaps(start, end time.Time) bool {
+	return s.StartsAt.Before(end) && start.Before(s.EndsAt)
+}
+
+// Client vincula a una consultora con una organización cliente (auditada). Los usuarios
+// del cliente son miembros de ClientOrgID y solo ven las auditorías realizadas sobre ella.
+type Client struct {
+	ID           string    `gorm:"primaryKey" json:"id"`
+	ConsultoraID string    `gorm:"not null;uniqueIndex:idx_client_pair" json:"consultora_id"`
+	ClientOrgID  string    `gorm:"not null;uniqueIndex:idx_client_pair;index" json:"client_org_id"`
+	Name         string    `gorm:"->;-:migration" json:"name"` // organizations.name, solo lectura
+	Sites        string    `json:"sites"`                      // Separados por coma, ej: "Planta Rosario,Depósito Funes"
+	ContactName  string    `json:"contact_name"`
+	ContactEmail string    `json:"contact_email"`
+	ContactPhone string    `json:"contact_phone"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// AuditProgramme es el programa de auditorías plurianual de una organización (ISO 19011 5.x):
+// declara qué procesos y cláusulas deben cubrirse y agrupa las auditorías planificadas por período
+type AuditProgramme struct {
+	ID             string    `gorm:"primaryKey" json:"id"`
+	OrganizationID string    `gorm:"not null;index" json:"org_id"`
+	Name           string    `gorm:"not null" json:"name"`
+	StartYear      int       `gorm:"not null" json:"start_year"`
+	EndYear        int       `gorm:"not null" json:"end_year"`
+	Objectives     string    `json:"objectives"`
+	Processes      string    `json:"processes"` // Separados por coma, ej: "Compras,Producción"
+	Clauses        string    `json:"clauses"`   // Separadas por coma, formato "<norma> <cláusula>", ej: "ISO 9001:2015 7.1.5"
+	CreatedBy      string    `gorm:"not null" json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ProgrammeEntry es una auditoría planificada dentro del programa. AuditID queda vacío
+// hasta que se genera la Audit concreta.
+type ProgrammeEntry struct {
+	ID          string    `gorm:"primaryKey" json:"id"`
+	ProgrammeID string    `gorm:"not null;index" json:"programme_id"`
+	Period      string    `gorm:"not null;index" json:"period"` // Ej: "2026-Q2", "2027-03"
+	Title       string    `gorm:"not null" json:"title"`
+	Processes   string    `json:"processes"`
+	Clauses     string    `json:"clauses"`
+	ClientID    string    `json:"client_id,omitempty"` // Opcional: cliente sobre el que se hará la auditoría
+	AuditID     string    `gorm:"index" json:"audit_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SplitList separa los campos de texto con valores separados por coma (procesos, cláusulas)
+func SplitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// Invitation es una invitación de un solo uso para sumarse al staff de una organización.
+// Solo se guarda el hash del token; el token en claro se entrega una única vez al invitado.
+type Invitation struct {
+	ID             string     `gorm:"primaryKey" json:"id"`
+	OrganizationID string     `gorm:"not null;index" json:"org_id"`
+	Email          string     `gorm:"not null;index" json:"email"`
+	Role           Role       `gorm:"not null" json:"role"`
+	TokenHash      string     `gorm:"not null;uniqueIndex" json:"-"`
+	InvitedBy      string     `gorm:"not null" json:"invited_by"`
+	ExpiresAt      time.Time  `gorm:"not null" json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// IsPending indica si la invitación todavía puede aceptarse
+func (i *Invitation) IsPending(now time.Time) bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil && now.Before(i.ExpiresAt)
 }
 
 // AuditAssignment vincula usuarios a auditorías (Tabla Vínculo Proyecto)
@@ -85,15 +299,468 @@ type AuditAssignment struct {
 	RoleInAudit      Role             `gorm:"not null" json:"role_in_audit"`
 	AcceptanceStatus AcceptanceStatus `gorm:"default:'Pendiente'" json:"acceptance_status"`
 	IsActive         bool             `gorm:"default:true" json:"is_active"`
-	TemporaryLink    string           `gorm:"index" json:"temporary_link,omitempty"`
+	RejectionReason  string           `json:"rejection_reason,omitempty"`
+	RespondedAt      *time.Time       `json:"responded_at,omitempty"`
 }
 
+// Standard es una versión de una norma del catálogo (ej: ISO 9001 versión 2015).
+// OrganizationID vacío = catálogo global; con valor = norma privada de esa consultora.
+type Standard struct {
+	ID             string    `gorm:"primaryKey" json:"id"`
+	Code           string    `gorm:"not null;uniqueIndex:idx_standard_version" json:"code"`    // Ej: "ISO 9001"
+	Version        string    `gorm:"not null;uniqueIndex:idx_standard_version" json:"version"` // Ej: "2015"
+	OrganizationID string    `gorm:"uniqueIndex:idx_standard_version" json:"org_id,omitempty"`
+	Title          string    `json:"title"`
+	CreatedBy      string    `json:"created_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Label es el nombre con el que la norma figura en el checklist ("ISO 9001:2015")
+func (s *Standard) Label() string {
+	return s.Code + ":" + s.Version
+}
+
+// Clause es una cláusula de una versión de norma. La jerarquía se arma por número:
+// "7.1.5" cuelga de ParentNumber "7.1".
+type Clause struct {
+	ID               string `gorm:"primaryKey" json:"id"`
+	StandardID       string `gorm:"not null;uniqueIndex:idx_clause_number;index" json:"standard_id"`
+	Number           string `gorm:"not null;uniqueIndex:idx_clause_number" json:"number"`
+	ParentNumber     string `json:"parent_number,omitempty"`
+	Title            string `json:"title"`
+	Requirement      string `json:"requirement"`
+	ExpectedEvidence string `json:"expected_evidence"`
+	Position         int    `gorm:"not null" json:"position"` // Orden dentro de la norma
+}
+
+// ChecklistTemplate es un set de preguntas reutilizable de una organización. Editar una
+// plantilla crea una versión nueva: las auditorías ya creadas apuntan a la versión que usaron.
+type ChecklistTemplate struct {
+	ID             string    `gorm:"primaryKey" json:"id"`
+	OrganizationID string    `gorm:"not null;index" json:"org_id"`
+	FamilyID       string    `gorm:"not null;uniqueIndex:idx_template_version" json:"family_id"` // ID de la primera versión; agrupa las versiones
+	Version        int       `gorm:"not null;uniqueIndex:idx_template_version" json:"version"`
+	Name           string    `gorm:"not null" json:"name"`
+	Description    string    `json:"description"`
+	CreatedBy      string    `gorm:"not null" json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ChecklistTemplateItem es una pregunta de la plantilla: una cláusula del catálogo
+// (ClauseID) o una pregunta propia de la consultora (ClauseID vacío)
+type ChecklistTemplateItem struct {
+	ID               string `gorm:"primaryKey" json:"id"`
+	TemplateID       string `gorm:"not null;index" json:"template_id"`
+	Position         int    `gorm:"not null" json:"position"`
+	ClauseID         string `json:"clause_id,omitempty"`
+	Standard         string `gorm:"not null" json:"standard"`
+	ClauseNumber     string `gorm:"not null" json:"clause_number"`
+	Requirement      string `gorm:"not null" json:"requirement"`
+	ExpectedEvidence string `json:"expected_evidence"`
+}
+
+// ChecklistItem es una cláusula ISO (9001/14001/45001...) instanciada en una auditoría
+type ChecklistItem struct {
+	ID               string          `gorm:"primaryKey" json:"id"`
+	AuditID          string          `gorm:"not null;index" json:"audit_id"`
+	Standard         string          `gorm:"not null" json:"standard"`      // Ej: "ISO 9001:2015"
+	ClauseNumber     string          `gorm:"not null" json:"clause_number"` // Ej: "7.1.5"
+	Requirement      string          `gorm:"not null" json:"requirement"`
+	ExpectedEvidence string          `json:"expected_evidence"`
+	ClauseID         string          `gorm:"index" json:"clause_id,omitempty"` // Cláusula del catálogo de la que proviene, si aplica
+	Answer           ChecklistAnswer `gorm:"default:'Pendiente'" json:"answer"`
+	Notes            string          `json:"notes"`
+	AnsweredBy       string          `json:"answered_by,omitempty"`
+	AnsweredAt       *time.Time      `json:"answered_at,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// Finding es un hallazgo (no conformidad, observación u oportunidad de mejora) de una auditoría
+type Finding struct {
+	ID              string          `gorm:"primaryKey" json:"id"`
+	AuditID         string          `gorm:"not null;index" json:"audit_id"`
+	ChecklistItemID string          `gorm:"index" json:"checklist_item_id,omitempty"` // Opcional: cláusula asociada
+	Severity        FindingSeverity `gorm:"not null" json:"severity"`
+	Description     string          `gorm:"not null" json:"description"`
+	EvidenceRef     string          `json:"evidence_ref"`
+	ReportedBy      string          `gorm:"not null;index" json:"reported_by"` // UserID del AuditAssignment que lo reporta
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt  `gorm:"index" json:"-"`
+}
+
+// CorrectiveAction es la acción correctiva (CAPA) que responde a una no conformidad
+type CorrectiveAction struct {
+	ID                string     `gorm:"primaryKey" json:"id"`
+	FindingID         string     `gorm:"not null;index" json:"finding_id"`
+	AuditID           string     `gorm:"not null;index" json:"audit_id"`
+	RootCause         string     `json:"root_cause"`
+	ActionPlan        string     `json:"action_plan"`
+	ResponsibleUserID string     `gorm:"not null;index" json:"responsible_user_id"`
+	ResponsibleOrgID  string     `gorm:"not null;index" json:"responsible_org_id"` // Organización del responsable (UserOrganization)
+	DueDate           time.Time  `gorm:"not null" json:"due_date"`
+	Status            CAPAStatus `gorm:"default:'Abierta'" json:"status"`
+	ReviewNotes       string     `json:"review_notes"`
+	CreatedBy         string     `gorm:"not null" json:"created_by"`
+	ImplementedAt     *time.Time `json:"implemented_at,omitempty"`
+	VerifiedBy        string     `json:"verified_by,omitempty"`
+	VerifiedAt        *time.Time `json:"verified_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// AuditStatusChange registra cada transición del ciclo de vida de una auditoría
+type AuditStatusChange struct {
+	ID         string      `gorm:"primaryKey" json:"id"`
+	AuditID    string      `gorm:"not null;index" json:"audit_id"`
+	FromStatus AuditStatus `gorm:"not null" json:"from_status"`
+	ToStatus   AuditStatus `gorm:"not null" json:"to_status"`
+	ChangedBy  string      `gorm:"not null" json:"changed_by"`
+	Reason     string      `json:"reason"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// Evidence es un archivo de evidencia objetiva (foto, PDF, registro) adjunto a una auditoría
+type Evidence struct {
+	ID              string         `gorm:"primaryKey" json:"id"`
+	AuditID         string         `gorm:"not null;index" json:"audit_id"`
+	FindingID       string         `gorm:"index" json:"finding_id,omitempty"`
+	ChecklistItemID string         `gorm:"index" json:"checklist_item_id,omitempty"`
+	UploadedBy      string         `gorm:"not null;index" json:"uploaded_by"` // UserID del AuditAssignment
+	FileName        string         `gorm:"not null" json:"file_name"`
+	ContentType     string         `gorm:"not null" json:"content_type"`
+	Size            int64          `gorm:"not null" json:"size"`
+	SHA256          string         `gorm:"not null;index" json:"sha256"`
+	StorageKey      string         `gorm:"not null" json:"-"`
+	CreatedAt       time.Time      `json:"created_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ReportTemplate personaliza el informe de auditoría de una organización (consultora)
+type ReportTemplate struct {
+	OrganizationID string    `gorm:"primaryKey" json:"org_id"`
+	Title          string    `json:"title"`
+	Header         string    `json:"header"` // Ej: razón social, nro. de acreditación
+	Intro          string    `json:"intro"`
+	Footer         string    `json:"footer"`
+	HTMLTemplate   string    `json:"html_template,omitempty"` // html/template completo, opcional (solo formato html)
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Session es un dispositivo con sesión iniciada. Su ID es el jti de los access tokens
+// y el refresh token rota en cada uso (solo se guarda el hash del vigente).
+type Session struct {
+	ID               string     `gorm:"primaryKey" json:"id"`
+	UserID           string     `gorm:"not null;index" json:"user_id"`
+	OrganizationID   string     `gorm:"not null" json:"org_id"`
+	Role             Role       `gorm:"not null" json:"role"`
+	RefreshTokenHash string     `gorm:"not null;uniqueIndex" json:"-"`
+	UserAgent        string     `json:"user_agent"`
+	IP               string     `json:"ip"`
+	CreatedAt        time.Time  `json:"created_at"`
+	LastUsedAt       time.Time  `json:"last_used_at"`
+	ExpiresAt        time.Time  `gorm:"not null;index" json:"expires_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty"`
+}
+
+// IsActive indica si la sesión todavía puede renovarse
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// TemporaryLink da acceso acotado a una auditoría a un participante externo (Auxiliar/Observador).
+// Solo se guarda el hash del token; el enlace en claro viaja únicamente en la notificación.
+type TemporaryLink struct {
+	ID         string     `gorm:"primaryKey" json:"id"`
+	AuditID    string     `gorm:"not null;index" json:"audit_id"`
+	UserID     string     `gorm:"not null;index" json:"user_id"` // AuditAssignment al que representa
+	TokenHash  string     `gorm:"not null;uniqueIndex" json:"-"`
+	Scopes     string     `gorm:"not null" json:"scopes"` // Separados por coma, ej: "view,evidence:upload"
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	MaxUses    int        `json:"max_uses"` // 0 = ilimitado
+	UseCount   int        `gorm:"default:0" json:"use_count"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy  string     `gorm:"not null" json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// IsUsable indica si el enlace no está revocado, vencido ni agotado
+func (l *TemporaryLink) IsUsable(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt) && (l.MaxUses == 0 || l.UseCount < l.MaxUses)
+}
+
+func (l *TemporaryLink) ScopeList() []LinkScope {
+	var scopes []LinkScope
+	for _, s := range strings.Split(l.Scopes, ",") {
+		if s != "" {
+			scopes = append(scopes, LinkScope(s))
+		}
+	}
+	return scopes
+}
+
+func (l *TemporaryLink) HasScope(scope LinkScope) bool {
+	for _, s := range l.ScopeList() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CalendarFeed es el token personal con el que un usuario suscribe su agenda de auditorías
+// desde su aplicación de calendario. Regenerarlo invalida la URL anterior.
+type CalendarFeed struct {
+	UserID        string     `gorm:"primaryKey" json:"user_id"`
+	TokenHash     string     `gorm:"not null;uniqueIndex" json:"-"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastFetchedAt *time.Time `json:"last_fetched_at,omitempty"`
+}
+
+// ActivityEntry es un registro de la bitácora de actividad: solo se insertan, nunca se modifican.
+// Cada organización tiene su propia cadena: Hash cubre los datos del registro y el Hash del
+// anterior (PrevHash), así que alterar o borrar un registro rompe la cadena desde ese punto.
+type ActivityEntry struct {
+	ID             string         `gorm:"primaryKey" json:"id"`
+	OrganizationID string         `gorm:"not null;uniqueIndex:idx_activity_seq" json:"org_id"`
+	Seq            int64          `gorm:"not null;uniqueIndex:idx_activity_seq" json:"seq"` // Posición en la cadena de la organización
+	ActorID        string         `gorm:"index" json:"actor_id"`
+	EntityType     ActivityEntity `gorm:"not null;index:idx_activity_entity" json:"entity_type"`
+	EntityID       string         `gorm:"not null;index:idx_activity_entity" json:"entity_id"`
+	Action         ActivityAction `gorm:"not null" json:"action"`
+	Changes        JSONText       `gorm:"type:text" json:"changes"` // Campo -> {"from", "to"}
+	RequestID      string         `gorm:"index" json:"request_id"`
+	IP             string         `json:"ip"`
+	CreatedAt      time.Time      `gorm:"not null;index" json:"created_at"`
+	PrevHash       string         `gorm:"not null" json:"prev_hash"`
+	Hash           string         `gorm:"not null" json:"hash"`
+}
+
+// ComputeHash calcula el SHA-256 del registro encadenado al anterior. CreatedAt se normaliza
+// a UTC para que el hash no dependa de la zona horaria con la que se lee de la base.
+func (e *ActivityEntry) ComputeHash() string {
+	h := sha256.New()
+	for _, field := range []string{
+		e.PrevHash,
+		strconv.FormatInt(e.Seq, 10),
+		e.OrganizationID,
+		e.ActorID,
+		string(e.EntityType),
+		e.EntityID,
+		string(e.Action),
+		string(e.Changes),
+		e.RequestID,
+		e.IP,
+		e.CreatedAt.UTC().Format(time.RFC3339Nano),
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// JSONText es JSON ya serializado que se guarda como texto y se devuelve sin volver a escaparlo
+type JSONText string
+
+func (j JSONText) MarshalJSON() ([]byte, error) {
+	if j == "" {
+		return []byte("null"), nil
+	}
+	return []byte(j), nil
+}
+
+// RevokedToken es la lista de denegación de access tokens por jti (ID de sesión),
+// necesaria solo hasta que vence el último access token emitido para la sesión.
 type RevokedToken struct {
 	ID        uint      `gorm:"primaryKey"`
-	Token     string    `gorm:"index;not null"`
+	JTI       string    `gorm:"index;not null"`
 	ExpiresAt time.Time `gorm:"not null;index"`
 }
 
+// --- MODELOS DE LECTURA (no persistidos) ---
+
+// AuditReport reúne todo lo necesario para renderizar el informe final
+type AuditReport struct {
+	Audit       Audit
+	Template    ReportTemplate
+	Team        []AuditReportMember
+	Checklist   []ChecklistItem
+	Findings    []Finding
+	Summary     map[ChecklistAnswer]int
+	GeneratedAt time.Time
+}
+
+type AuditReportMember struct {
+	Email            string
+	RoleInAudit      Role
+	AcceptanceStatus AcceptanceStatus
+}
+
+// OrganizationMembership es una membresía del usuario con el nombre de la organización
+type OrganizationMembership struct {
+	OrganizationID string       `json:"org_id"`
+	Name           string       `json:"name"`
+	RoleDefault    Role         `json:"role_default"`
+	Status         MemberStatus `json:"status"`
+	JoinedAt       time.Time    `json:"joined_at"`
+}
+
+type NotificationKind string
+
+const (
+	NotifyInvitation      NotificationKind = "invitation"
+	NotifyAuditAssignment NotificationKind = "audit_assignment"
+	NotifyAuditStatus     NotificationKind = "audit_status"
+	NotifyAssignmentReply NotificationKind = "assignment_reply" // Aviso al líder de la respuesta a una asignación
+)
+
+// Notification es un mensaje saliente; el adaptador resuelve la plantilla según Kind y Locale
+type Notification struct {
+	To     string
+	Locale string // "es" (por defecto) o "en"
+	Kind   NotificationKind
+	Data   map[string]string
+}
+
+// TokenPair es la respuesta de login/refresh. El access token conserva la clave "token".
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"` // Segundos de vida del access token
+	SessionID    string `json:"session_id"`
+}
+
+// ClientInfo identifica el dispositivo que abre la sesión
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// ActivityFilter acota la consulta de la bitácora; los campos vacíos no filtran
+type ActivityFilter struct {
+	EntityType ActivityEntity
+	EntityID   string
+	ActorID    string
+	Action     ActivityAction
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	BeforeSeq  int64 // Paginación hacia atrás: solo registros con Seq menor (0 = desde el último)
+	Limit      int
+}
+
+// ActivityVerification es el resultado de recorrer la cadena de una organización
+type ActivityVerification struct {
+	Valid       bool  `json:"valid"`
+	Checked     int64 `json:"checked"`
+	BrokenAtSeq int64 `json:"broken_at_seq,omitempty"` // Primer registro cuyo hash no coincide
+}
+
+// PublicAuditView es lo único que ve un externo de la auditoría a través de un enlace temporal
+type PublicAuditView struct {
+	ID        string      `json:"id"`
+	Title     string      `json:"title"`
+	Status    AuditStatus `json:"status"`
+	Scopes    []LinkScope `json:"scopes"`
+	ExpiresAt time.Time   `json:"expires_at"`
+}
+
+// AuditPlan es el plan de auditoría completo: ventana planificada y agenda de sesiones
+type AuditPlan struct {
+	AuditID      string         `json:"audit_id"`
+	PlannedStart *time.Time     `json:"planned_start,omitempty"`
+	PlannedEnd   *time.Time     `json:"planned_end,omitempty"`
+	Sessions     []AuditSession `json:"sessions"`
+}
+
+// ScheduleConflict es una doble reserva: el auditor de Session ya tiene asignada
+// la sesión With (de esta u otra auditoría) en un horario superpuesto. Si la otra
+// auditoría es de otra organización solo se informa el horario ocupado (Busy).
+type ScheduleConflict struct {
+	Session AuditSession  `json:"session"`
+	With    *AuditSession `json:"with,omitempty"`
+	Busy    *BusySlot     `json:"busy,omitempty"`
+}
+
+// BusySlot es una franja ocupada del auditor, sin datos de la auditoría a la que pertenece
+type BusySlot struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+type CalendarEventStatus string
+
+const (
+	EventConfirmed CalendarEventStatus = "CONFIRMED"
+	EventCancelled CalendarEventStatus = "CANCELLED"
+)
+
+// CalendarEvent es un evento del feed iCalendar (una auditoría o una sesión de su agenda).
+// Sequence crece con cada cambio para que los clientes reemplacen la versión anterior.
+type CalendarEvent struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	Start        time.Time
+	End          time.Time
+	Status       CalendarEventStatus
+	Sequence     int
+	LastModified time.Time
+}
+
+type CoverageStatus string
+
+const (
+	CoverageGap     CoverageStatus = "Sin_Cubrir"
+	CoveragePlanned CoverageStatus = "Planificada"
+	CoverageAudited CoverageStatus = "Auditada"
+)
+
+// CoverageItem es el estado de cobertura de un proceso o cláusula del programa
+type CoverageItem struct {
+	Key     string         `json:"key"`
+	Status  CoverageStatus `json:"status"`
+	Periods []string       `json:"periods"` // Períodos en los que está planificada
+}
+
+// ProgrammeCoverage cruza lo que el programa debe cubrir con lo planificado y lo auditado
+type ProgrammeCoverage struct {
+	ProgrammeID string         `json:"programme_id"`
+	Processes   []CoverageItem `json:"processes"`
+	Clauses     []CoverageItem `json:"clauses"`
+	ProcessGaps []string       `json:"process_gaps"`
+	ClauseGaps  []string       `json:"clause_gaps"`
+	Undeclared  []string       `json:"undeclared"` // Planificados en alguna entrada pero no declarados en el programa
+}
+
+// ClauseNode es una cláusula con sus subcláusulas
+type ClauseNode struct {
+	Clause
+	Children []ClauseNode `json:"children"`
+}
+
+// StandardDetail es una norma con su árbol de cláusulas
+type StandardDetail struct {
+	Standard
+	Clauses []ClauseNode `json:"clauses"`
+}
+
+// ChecklistTemplateDetail es una versión de plantilla con sus preguntas
+type ChecklistTemplateDetail struct {
+	ChecklistTemplate
+	Items []ChecklistTemplateItem `json:"items"`
+}
+
+// ReportFile es un informe ya renderizado listo para descargar
+type ReportFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
 // --- HOOKS (Generación de UUIDs) ---
 
 func (o *Organization) BeforeCreate(tx *gorm.DB) (err error) {
@@ -116,3 +783,118 @@ func (a *Audit) BeforeCreate(tx *gorm.DB) (err error) {
 	}
 	return
 }
+
+func (c *ChecklistItem) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return
+}
+
+func (f *Finding) BeforeCreate(tx *gorm.DB) (err error) {
+	if f.ID == "" {
+		f.ID = uuid.New().String()
+	}
+	return
+}
+
+func (c *CorrectiveAction) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return
+}
+
+func (a *AuditStatusChange) BeforeCreate(tx *gorm.DB) (err error) {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return
+}
+
+func (e *Evidence) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return
+}
+
+func (i *Invitation) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.ID == "" {
+		i.ID = uuid.New().String()
+	}
+	return
+}
+
+func (l *TemporaryLink) BeforeCreate(tx *gorm.DB) (err error) {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	return
+}
+
+func (s *AuditSession) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return
+}
+
+func (p *AuditProgramme) BeforeCreate(tx *gorm.DB) (err error) {
+	if p.ID == "" {
+		p.ID = uuid.New().String()
+	}
+	return
+}
+
+func (e *ProgrammeEntry) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return
+}
+
+func (c *Client) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return
+}
+
+func (s *Standard) BeforeCreate(tx *gorm.DB) (err error) {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return
+}
+
+func (c *Clause) BeforeCreate(tx *gorm.DB) (err error) {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return
+}
+
+func (t *ChecklistTemplate) BeforeCreate(tx *gorm.DB) (err error) {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	if t.FamilyID == "" {
+		t.FamilyID = t.ID
+	}
+	return
+}
+
+func (i *ChecklistTemplateItem) BeforeCreate(tx *gorm.DB) (err error) {
+	if i.ID == "" {
+		i.ID = uuid.New().String()
+	}
+	return
+}
+
+func (e *ActivityEntry) BeforeCreate(tx *gorm.DB) (err error) {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return
+}
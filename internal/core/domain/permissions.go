@@ -0,0 +1,105 @@
+package domain
+
+// Action es un permiso atómico que se evalúa contra un rol
+type Action string
+
+// Acciones a nivel organización (UserOrganization.RoleDefault)
+const (
+	ActionStaffInvite    Action = "staff:invite"
+	ActionStaffList      Action = "staff:list"
+	ActionStaffUpdate    Action = "staff:update"
+	ActionAuditCreate    Action = "audit:create"
+	ActionAuditAssign    Action = "audit:assign"
+	ActionReportTemplate Action = "report:template"
+	ActionProgrammeRead  Action = "programme:read"
+	ActionProgrammeEdit  Action = "programme:edit"
+	ActionClientManage   Action = "client:manage"
+	ActionStandardManage Action = "standard:manage" // Normas privadas del catálogo
+	ActionTemplateManage Action = "template:manage" // Plantillas de checklist
+	ActionAuditeeRead    Action = "auditee:read"    // Ver las auditorías realizadas sobre la propia organización
+	ActionActivityRead   Action = "activity:read"   // Consultar y verificar la bitácora de actividad
+	ActionCAPARead       Action = "capa:read"       // Ver las CAPAs a cargo de la organización
+)
+
+// Acciones a nivel auditoría (AuditAssignment.RoleInAudit)
+const (
+	ActionAuditRead         Action = "audit:read"
+	ActionAuditTransition   Action = "audit:transition"
+	ActionAuditFinalize     Action = "audit:finalize"
+	ActionAuditPlan         Action = "audit:plan"
+	ActionChecklistEdit     Action = "checklist:edit"
+	ActionChecklistAnswer   Action = "checklist:answer"
+	ActionFindingWrite      Action = "finding:write"
+	ActionEvidenceUpload    Action = "evidence:upload"
+	ActionEvidenceDeleteAny Action = "evidence:delete_any"
+	ActionCAPACreate        Action = "capa:create"
+	ActionCAPAReview        Action = "capa:review"
+	ActionReportRead        Action = "report:read"
+	ActionReportConclusions Action = "report:conclusions"
+)
+
+// IsReadOnly: las acciones de lectura solo exigen una asignación activa;
+// el resto exige además que la asignación haya sido aceptada
+func (a Action) IsReadOnly() bool {
+	return a == ActionAuditRead || a == ActionReportRead
+}
+
+// orgPolicy: rol en la organización -> acciones permitidas
+var orgPolicy = map[Role][]Action{
+	RoleConsultora: {
+		ActionStaffInvite, ActionStaffList, ActionStaffUpdate,
+		ActionAuditCreate, ActionAuditAssign, ActionReportTemplate,
+		ActionProgrammeRead, ActionProgrammeEdit, ActionClientManage,
+		ActionStandardManage, ActionTemplateManage, ActionActivityRead, ActionCAPARead,
+	},
+	RoleAuditorLider: {
+		ActionStaffList, ActionAuditCreate, ActionAuditAssign,
+		ActionProgrammeRead, ActionProgrammeEdit, ActionClientManage,
+		ActionStandardManage, ActionTemplateManage, ActionActivityRead, ActionCAPARead,
+	},
+	RoleAuditorInterno: {ActionStaffList, ActionProgrammeRead, ActionCAPARead},
+	RoleAuxiliar:       {ActionCAPARead},
+	RoleObservador:     {ActionCAPARead},
+	RoleCliente:        {ActionAuditeeRead, ActionCAPARead},
+}
+
+// auditPolicy: rol dentro de la auditoría -> acciones permitidas
+var auditPolicy = map[Role][]Action{
+	RoleAuditorLider: {
+		ActionAuditRead, ActionAuditTransition, ActionAuditFinalize, ActionAuditAssign, ActionAuditPlan,
+		ActionChecklistEdit, ActionChecklistAnswer, ActionFindingWrite,
+		ActionEvidenceUpload, ActionEvidenceDeleteAny,
+		ActionCAPACreate, ActionCAPAReview,
+		ActionReportRead, ActionReportConclusions,
+	},
+	RoleAuditorInterno: {
+		ActionAuditRead, ActionAuditTransition,
+		ActionChecklistEdit, ActionChecklistAnswer, ActionFindingWrite,
+		ActionEvidenceUpload, ActionCAPACreate, ActionReportRead,
+	},
+	RoleAuxiliar: {
+		ActionAuditRead, ActionChecklistAnswer, ActionFindingWrite,
+		ActionEvidenceUpload, ActionReportRead,
+	},
+	RoleObservador: {ActionAuditRead, ActionReportRead},
+	RoleConsultora: {ActionAuditRead, ActionReportRead},
+}
+
+func allows(policy map[Role][]Action, role Role, action Action) bool {
+	for _, a := range policy[role] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// OrgRoleAllows evalúa una acción contra el rol del usuario en la organización
+func OrgRoleAllows(role Role, action Action) bool {
+	return allows(orgPolicy, role, action)
+}
+
+// AuditRoleAllows evalúa una acción contra el rol del usuario dentro de una auditoría
+func AuditRoleAllows(role Role, action Action) bool {
+	return allows(auditPolicy, role, action)
+}
@@ -0,0 +1,77 @@
+package domain
+
+import "testing"
+
+var (
+	orgActions = []Action{
+		ActionStaffInvite, ActionStaffList, ActionStaffUpdate, ActionAuditCreate, ActionAuditAssign,
+		ActionReportTemplate, ActionProgrammeRead, ActionProgrammeEdit, ActionClientManage,
+		ActionStandardManage, ActionTemplateManage, ActionAuditeeRead, ActionActivityRead, ActionCAPARead,
+	}
+	auditActions = []Action{
+		ActionAuditRead, ActionAuditTransition, ActionAuditFinalize, ActionAuditAssign, ActionAuditPlan,
+		ActionChecklistEdit, ActionChecklistAnswer, ActionFindingWrite, ActionEvidenceUpload,
+		ActionEvidenceDeleteAny, ActionCAPACreate, ActionCAPAReview, ActionReportRead, ActionReportConclusions,
+	}
+	roles = []Role{RoleConsultora, RoleAuditorLider, RoleAuditorInterno, RoleAuxiliar, RoleObservador, RoleCliente}
+)
+
+// La matriz se escribe a mano a propósito: un cambio en la política tiene que verse también acá
+func TestOrgRoleAllows(t *testing.T) {
+	allowed := map[Role][]Action{
+		RoleConsultora: {
+			ActionStaffInvite, ActionStaffList, ActionStaffUpdate, ActionAuditCreate, ActionAuditAssign,
+			ActionReportTemplate, ActionProgrammeRead, ActionProgrammeEdit, ActionClientManage,
+			ActionStandardManage, ActionTemplateManage, ActionActivityRead, ActionCAPARead,
+		},
+		RoleAuditorLider: {
+			ActionStaffList, ActionAuditCreate, ActionAuditAssign, ActionProgrammeRead, ActionProgrammeEdit,
+			ActionClientManage, ActionStandardManage, ActionTemplateManage, ActionActivityRead, ActionCAPARead,
+		},
+		RoleAuditorInterno: {ActionStaffList, ActionProgrammeRead, ActionCAPARead},
+		RoleAuxiliar:       {ActionCAPARead},
+		RoleObservador:     {ActionCAPARead},
+		RoleCliente:        {ActionAuditeeRead, ActionCAPARead},
+	}
+	checkMatrix(t, OrgRoleAllows, orgActions, allowed)
+}
+
+func TestAuditRoleAllows(t *testing.T) {
+	allowed := map[Role][]Action{
+		RoleAuditorLider: auditActions,
+		RoleAuditorInterno: {
+			ActionAuditRead, ActionAuditTransition, ActionChecklistEdit, ActionChecklistAnswer,
+			ActionFindingWrite, ActionEvidenceUpload, ActionCAPACreate, ActionReportRead,
+		},
+		RoleAuxiliar: {
+			ActionAuditRead, ActionChecklistAnswer, ActionFindingWrite, ActionEvidenceUpload, ActionReportRead,
+		},
+		RoleObservador: {ActionAuditRead, ActionReportRead},
+		RoleConsultora: {ActionAuditRead, ActionReportRead},
+	}
+	checkMatrix(t, AuditRoleAllows, auditActions, allowed)
+}
+
+func checkMatrix(t *testing.T, allows func(Role, Action) bool, actions []Action, allowed map[Role][]Action) {
+	t.Helper()
+	for _, role := range append(roles, Role("Desconocido")) {
+		want := map[Action]bool{}
+		for _, action := range allowed[role] {
+			want[action] = true
+		}
+		for _, action := range actions {
+			if got := allows(role, action); got != want[action] {
+				t.Errorf("%s / %s: got %v, want %v", role, action, got, want[action])
+			}
+		}
+	}
+}
+
+func TestActionIsReadOnly(t *testing.T) {
+	for _, action := range auditActions {
+		want := action == ActionAuditRead || action == ActionReportRead
+		if got := action.IsReadOnly(); got != want {
+			t.Errorf("%s.IsReadOnly() = %v, want %v", action, got, want)
+		}
+	}
+}
@@ -1,28 +1,227 @@
 package ports
 
-import "github.com/RiosHectorM/iso-stack/internal/core/domain"
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
 
 type AuthRepository interface {
-	CreateUserWithOrg(user *domain.User, org *domain.Organization, userOrg *domain.UserOrganization) error
-	FindUserByEmail(email string) (*domain.User, error)
-	GetUserPrimaryOrg(userID string) (*domain.UserOrganization, error)
-	RevokeToken(token string, expirationTime int64) error // expirationTime podría ser time.Time
-	IsTokenRevoked(token string) (bool, error)
+	CreateUserWithOrg(ctx context.Context, user *domain.User, org *domain.Organization, userOrg *domain.UserOrganization) error
+	FindUserByEmail(ctx context.Context, email string) (*domain.User, error)
+	FindUserByID(ctx context.Context, userID string) (*domain.User, error)
+	GetUserPrimaryOrg(ctx context.Context, userID string) (*domain.UserOrganization, error)
+	ListUserOrganizations(ctx context.Context, userID string) ([]domain.OrganizationMembership, error)
+	FindMembership(ctx context.Context, userID, orgID string) (*domain.UserOrganization, error)
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, jti string) (bool, error)
+
+	// Sessions
+	CreateSession(ctx context.Context, session *domain.Session) error
+	FindSession(ctx context.Context, sessionID string) (*domain.Session, error)
+	// RotateSession reemplaza el hash del refresh token solo si sigue siendo oldHash
+	RotateSession(ctx context.Context, sessionID, oldHash, newHash string, expiresAt time.Time) error
+	ListActiveSessions(ctx context.Context, userID string) ([]domain.Session, error)
+	RevokeSession(ctx context.Context, sessionID string) error
 }
 
 type OrganizationRepository interface {
-	AddUserToOrg(userOrg *domain.UserOrganization) error
-	CreateUserAndAddToOrg(user *domain.User, userOrg *domain.UserOrganization) error
-	ListOrgStaff(orgID string) ([]domain.UserOrganization, error)
-	FindUserOrg(userID, orgID string) (*domain.UserOrganization, error)
-	UpdateUserStatus(userID, orgID string, status domain.MemberStatus) error
+	FindOrganization(ctx context.Context, orgID string) (*domain.Organization, error)
+	AddUserToOrg(ctx context.Context, userOrg *domain.UserOrganization) error
+	CreateUserAndAddToOrg(ctx context.Context, user *domain.User, userOrg *domain.UserOrganization) error
+	ListOrgStaff(ctx context.Context, orgID string) ([]domain.UserOrganization, error)
+	FindUserOrg(ctx context.Context, userID, orgID string) (*domain.UserOrganization, error)
+	UpdateUserStatus(ctx context.Context, userID, orgID string, status domain.MemberStatus) error
+}
+
+// ClientRepository gestiona los clientes de una consultora. Todas las consultas
+// filtran por consultoraID: una consultora nunca ve los clientes de otra.
+type ClientRepository interface {
+	CreateClient(ctx context.Context, client *domain.Client, org *domain.Organization) error // Crea la organización cliente y el vínculo
+	FindClient(ctx context.Context, consultoraID, clientID string) (*domain.Client, error)
+	ListClients(ctx context.Context, consultoraID string) ([]domain.Client, error)
+	UpdateClient(ctx context.Context, client *domain.Client) error
 }
 
 type AuditRepository interface {
-	CreateAudit(audit *domain.Audit) error
-	AssignUserToAudit(assignment *domain.AuditAssignment) error
-	GetAuditsByUserID(userID string) ([]domain.Audit, error)
-	GetAuditByTempLink(tempLink string) (*domain.Audit, error)
-	GetAuditByID(auditID string) (*domain.Audit, error)
-	FindAuditAssignment(auditID, userID string) (*domain.AuditAssignment, error)
+	CreateAudit(ctx context.Context, audit *domain.Audit) error
+	// AssignUserToAudit reactiva la asignación inactiva del usuario si la hay; una activa es ErrDuplicatedKey
+	AssignUserToAudit(ctx context.Context, assignment *domain.AuditAssignment) error
+	// ReplaceAuditAssignment desactiva la asignación rechazada y asigna el reemplazo en una sola transacción
+	ReplaceAuditAssignment(ctx context.Context, declined, replacement *domain.AuditAssignment) error
+	GetAuditsByUserID(ctx context.Context, userID string, acceptance domain.AcceptanceStatus) ([]domain.Audit, error) // acceptance vacío = todas
+	GetAuditByID(ctx context.Context, auditID string) (*domain.Audit, error)
+	// Vista del auditado: siempre filtradas por auditee_org_id
+	ListAuditsForAuditee(ctx context.Context, auditeeOrgID string) ([]domain.Audit, error)
+	GetAuditForAuditee(ctx context.Context, auditeeOrgID, auditID string) (*domain.Audit, error)
+	FindAuditAssignment(ctx context.Context, auditID, userID string) (*domain.AuditAssignment, error)
+	ListAuditAssignments(ctx context.Context, auditID string) ([]domain.AuditAssignment, error)
+	UpdateAuditAssignment(ctx context.Context, assignment *domain.AuditAssignment) error
+	UpdateAuditConclusions(ctx context.Context, auditID, conclusions string) error
+
+	// Checklist
+	CreateChecklistItems(ctx context.Context, items []domain.ChecklistItem) error
+	GetChecklistByAuditID(ctx context.Context, auditID string) ([]domain.ChecklistItem, error)
+	FindChecklistItem(ctx context.Context, auditID, itemID string) (*domain.ChecklistItem, error)
+	UpdateChecklistItem(ctx context.Context, item *domain.ChecklistItem) error
+
+	// Temporary links
+	CreateTemporaryLink(ctx context.Context, link *domain.TemporaryLink) error
+	FindTemporaryLink(ctx context.Context, linkID string) (*domain.TemporaryLink, error)
+	FindTemporaryLinkByHash(ctx context.Context, tokenHash string) (*domain.TemporaryLink, error)
+	ListTemporaryLinks(ctx context.Context, auditID string) ([]domain.TemporaryLink, error)
+	RevokeTemporaryLink(ctx context.Context, auditID, linkID string) error
+	// RegisterTemporaryLinkUse incrementa el contador solo si el enlace sigue siendo usable
+	RegisterTemporaryLinkUse(ctx context.Context, linkID string) error
+
+	// Lifecycle
+	UpdateAuditStatus(ctx context.Context, change *domain.AuditStatusChange) error // Falla si el estado actual ya no es change.FromStatus
+	ListAuditStatusHistory(ctx context.Context, auditID string) ([]domain.AuditStatusChange, error)
+}
+
+type PlanningRepository interface {
+	UpdateAuditSchedule(ctx context.Context, auditID string, plannedStart, plannedEnd *time.Time) error
+	CreateAuditSession(ctx context.Context, session *domain.AuditSession) error
+	ListAuditSessions(ctx context.Context, auditID string) ([]domain.AuditSession, error)
+	FindAuditSession(ctx context.Context, auditID, sessionID string) (*domain.AuditSession, error)
+	UpdateAuditSession(ctx context.Context, session *domain.AuditSession) error
+	DeleteAuditSession(ctx context.Context, auditID, sessionID string) error
+	// FindOverlappingSessions busca sesiones del auditor que se superponen con [start, end)
+	// en auditorías no finalizadas, excluyendo excludeID
+	FindOverlappingSessions(ctx context.Context, auditorID string, start, end time.Time, excludeID string) ([]domain.AuditSession, error)
+	ListAuditorSessions(ctx context.Context, auditorID string) ([]domain.AuditSession, error)
+}
+
+type CalendarRepository interface {
+	SaveCalendarFeed(ctx context.Context, feed *domain.CalendarFeed) error // Crea o reemplaza el feed del usuario
+	FindCalendarFeedByHash(ctx context.Context, tokenHash string) (*domain.CalendarFeed, error)
+	TouchCalendarFeed(ctx context.Context, userID string, fetchedAt time.Time) error
+	DeleteCalendarFeed(ctx context.Context, userID string) error
+}
+
+type ProgrammeRepository interface {
+	CreateProgramme(ctx context.Context, programme *domain.AuditProgramme) error
+	FindProgramme(ctx context.Context, orgID, programmeID string) (*domain.AuditProgramme, error)
+	ListProgrammes(ctx context.Context, orgID string) ([]domain.AuditProgramme, error)
+	UpdateProgramme(ctx context.Context, programme *domain.AuditProgramme) error
+	CreateProgrammeEntry(ctx context.Context, entry *domain.ProgrammeEntry) error
+	FindProgrammeEntry(ctx context.Context, programmeID, entryID string) (*domain.ProgrammeEntry, error)
+	ListProgrammeEntries(ctx context.Context, programmeID string) ([]domain.ProgrammeEntry, error)
+	// SwapProgrammeEntryAudit cambia audit_id de current a next solo si sigue valiendo current (si no, ErrConflict)
+	SwapProgrammeEntryAudit(ctx context.Context, programmeID, entryID, current, next string) error
+	DeleteProgrammeEntry(ctx context.Context, programmeID, entryID string) error
+}
+
+// StandardRepository es el catálogo de normas. orgID limita la visibilidad a las
+// normas globales más las privadas de esa organización.
+type StandardRepository interface {
+	CreateStandard(ctx context.Context, standard *domain.Standard, clauses []domain.Clause) error // Norma y cláusulas en una transacción
+	ListStandards(ctx context.Context, orgID string) ([]domain.Standard, error)
+	FindStandard(ctx context.Context, orgID, standardID string) (*domain.Standard, error)
+	ListClauses(ctx context.Context, standardID string) ([]domain.Clause, error)
+	FindClause(ctx context.Context, clauseID string) (*domain.Clause, error)
+	DeleteStandard(ctx context.Context, orgID, standardID string) error // Solo normas privadas de orgID
+}
+
+// ChecklistTemplateRepository filtra siempre por organización dueña
+type ChecklistTemplateRepository interface {
+	CreateChecklistTemplate(ctx context.Context, tpl *domain.ChecklistTemplate, items []domain.ChecklistTemplateItem) error
+	FindChecklistTemplate(ctx context.Context, orgID, templateID string) (*domain.ChecklistTemplate, error)
+	ListChecklistTemplates(ctx context.Context, orgID string) ([]domain.ChecklistTemplate, error) // Última versión de cada plantilla
+	ListChecklistTemplateVersions(ctx context.Context, orgID, familyID string) ([]domain.ChecklistTemplate, error)
+	ListChecklistTemplateItems(ctx context.Context, templateID string) ([]domain.ChecklistTemplateItem, error)
+}
+
+type FindingRepository interface {
+	CreateFinding(ctx context.Context, finding *domain.Finding) error
+	ListFindingsByAudit(ctx context.Context, auditID string) ([]domain.Finding, error)
+	FindFinding(ctx context.Context, auditID, findingID string) (*domain.Finding, error)
+	UpdateFinding(ctx context.Context, finding *domain.Finding) error
+	DeleteFinding(ctx context.Context, auditID, findingID string) error
+	ListFindingsForAuditee(ctx context.Context, auditeeOrgID, auditID string) ([]domain.Finding, error)
+}
+
+type CAPARepository interface {
+	CreateCAPA(ctx context.Context, capa *domain.CorrectiveAction) error
+	FindCAPA(ctx context.Context, capaID string) (*domain.CorrectiveAction, error)
+	ListCAPAsByAudit(ctx context.Context, auditID string) ([]domain.CorrectiveAction, error)
+	ListCAPAsByOrg(ctx context.Context, orgID string) ([]domain.CorrectiveAction, error)
+	UpdateCAPA(ctx context.Context, capa *domain.CorrectiveAction) error
+}
+
+type EvidenceRepository interface {
+	CreateEvidence(ctx context.Context, evidence *domain.Evidence) error
+	ListEvidenceByAudit(ctx context.Context, auditID string) ([]domain.Evidence, error)
+	FindEvidence(ctx context.Context, auditID, evidenceID string) (*domain.Evidence, error)
+	DeleteEvidence(ctx context.Context, auditID, evidenceID string) error
+}
+
+// BlobStore abstrae el almacenamiento binario de evidencias (filesystem local, S3, MinIO...)
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type ReportTemplateRepository interface {
+	GetReportTemplate(ctx context.Context, orgID string) (*domain.ReportTemplate, error)
+	SaveReportTemplate(ctx context.Context, tpl *domain.ReportTemplate) error
+}
+
+// ReportRenderer convierte un AuditReport a un formato concreto (pdf, docx, html)
+type ReportRenderer interface {
+	Render(w io.Writer, report *domain.AuditReport) error
+	ContentType() string
+}
+
+type InvitationRepository interface {
+	CreateInvitation(ctx context.Context, inv *domain.Invitation) error
+	FindInvitation(ctx context.Context, orgID, invitationID string) (*domain.Invitation, error)
+	FindInvitationByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error)
+	ListInvitations(ctx context.Context, orgID string) ([]domain.Invitation, error)
+	UpdateInvitation(ctx context.Context, inv *domain.Invitation) error
+	// AcceptInvitation crea el usuario (si newUser != nil), activa la membresía y marca la invitación como usada
+	AcceptInvitation(ctx context.Context, inv *domain.Invitation, newUser *domain.User, userOrg *domain.UserOrganization) error
+}
+
+// ActivityRepository es append-only: no expone modificación ni borrado de registros
+type ActivityRepository interface {
+	// AppendActivity asigna Seq, PrevHash y Hash encadenando con el último registro de la organización
+	AppendActivity(ctx context.Context, entry *domain.ActivityEntry) error
+	// ListActivity devuelve los registros filtrados, del más reciente al más antiguo
+	ListActivity(ctx context.Context, orgID string, filter domain.ActivityFilter) ([]domain.ActivityEntry, error)
+	// ListActivityChain devuelve hasta limit registros con Seq mayor a afterSeq, en orden de cadena
+	ListActivityChain(ctx context.Context, orgID string, afterSeq int64, limit int) ([]domain.ActivityEntry, error)
+}
+
+// AdminRepository son las operaciones de mantenimiento de isoctl, sin equivalente en la API
+type AdminRepository interface {
+	UpdateUserPassword(ctx context.Context, userID, passwordHash string) error
+	UpdateMemberRole(ctx context.Context, userID, orgID string, role domain.Role) error
+	// PurgeExpiredRevokedTokens borra los jti revocados cuyo access token ya venció
+	PurgeExpiredRevokedTokens(ctx context.Context, now time.Time) (int64, error)
+	ListOrgAudits(ctx context.Context, orgID string) ([]domain.Audit, error) // Auditorías de las que orgID es dueña
+}
+
+// Repositories reúne todos los puertos de persistencia: es lo que implementa cada backend
+// de base de datos (Postgres, SQLite)
+type Repositories interface {
+	AuthRepository
+	OrganizationRepository
+	ClientRepository
+	AuditRepository
+	PlanningRepository
+	CalendarRepository
+	ProgrammeRepository
+	StandardRepository
+	ChecklistTemplateRepository
+	FindingRepository
+	CAPARepository
+	EvidenceRepository
+	ReportTemplateRepository
+	InvitationRepository
+	ActivityRepository
+	AdminRepository
 }
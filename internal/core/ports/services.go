@@ -1,22 +1,182 @@
 package ports
 
-import "github.com/RiosHectorM/iso-stack/internal/core/domain"
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
 
 type AuthService interface {
-	Register(email, password, orgName string) (string, error)
-	Login(email, password string) (string, error)
-	Logout(token string) error
+	Register(ctx context.Context, email, password, orgName string, client domain.ClientInfo) (*domain.TokenPair, error)
+	Login(ctx context.Context, email, password, orgID string, client domain.ClientInfo) (*domain.TokenPair, error) // orgID opcional: vacío = organización principal
+	Logout(ctx context.Context, sessionID string) error
+	ListOrganizations(ctx context.Context, userID string) ([]domain.OrganizationMembership, error)
+	SwitchOrganization(ctx context.Context, userID, orgID, sessionID string, client domain.ClientInfo) (*domain.TokenPair, error)
+
+	// Sessions
+	Refresh(ctx context.Context, refreshToken string, client domain.ClientInfo) (*domain.TokenPair, error)
+	ListSessions(ctx context.Context, userID string) ([]domain.Session, error)
+	RevokeSession(ctx context.Context, userID, sessionID string) error
 }
 
 type OrganizationService interface {
-	InviteStaff(email, role, orgID string) error
-	ListStaff(orgID string) ([]map[string]interface{}, error)
-	UpdateStaffStatus(userID, orgID, status string) error
+	InviteStaff(ctx context.Context, email, role, orgID, invitedBy string) (*domain.Invitation, error)
+	ListStaff(ctx context.Context, orgID string) ([]map[string]interface{}, error)
+	UpdateStaffStatus(ctx context.Context, userID, orgID, status, actorID string) error
+
+	// Invitaciones
+	ListInvitations(ctx context.Context, orgID, userID string) ([]domain.Invitation, error)
+	ResendInvitation(ctx context.Context, orgID, invitationID, userID string) (*domain.Invitation, error)
+	RevokeInvitation(ctx context.Context, orgID, invitationID, userID string) error
+	AcceptInvitation(ctx context.Context, token, password string) error
+}
+
+type ClientService interface {
+	CreateClient(ctx context.Context, consultoraID, actorID, name string, input *domain.Client) (*domain.Client, error)
+	ListClients(ctx context.Context, consultoraID, actorID string) ([]domain.Client, error)
+	GetClient(ctx context.Context, consultoraID, clientID, actorID string) (*domain.Client, error)
+	UpdateClient(ctx context.Context, consultoraID, clientID, actorID string, input *domain.Client) (*domain.Client, error)
+	// InviteClientUser invita a un usuario a la organización cliente con rol Cliente
+	InviteClientUser(ctx context.Context, consultoraID, clientID, actorID, email string) (*domain.Invitation, error)
+}
+
+// AuditeeService es la vista de solo lectura de una organización auditada
+type AuditeeService interface {
+	ListAudits(ctx context.Context, orgID, userID string) ([]domain.Audit, error)
+	GetAudit(ctx context.Context, orgID, auditID, userID string) (*domain.Audit, error)
+	ListFindings(ctx context.Context, orgID, auditID, userID string) ([]domain.Finding, error)
 }
 
 type AuditService interface {
-	CreateAudit(title, orgOwnerID, userID string) (*domain.Audit, error)
-	AssignStaff(auditID, userID, role, orgID string) error
-	GetMyAudits(userID string) ([]domain.Audit, error)
-	GetPublicAudit(tempLink string) (*domain.Audit, error)
+	// clientID vacío = auditoría interna; templateID vacío = checklist vacío
+	CreateAudit(ctx context.Context, title, orgOwnerID, userID, clientID, templateID string) (*domain.Audit, error)
+	AssignStaff(ctx context.Context, auditID, actorID, userID, role, orgID string) error
+	GetMyAudits(ctx context.Context, userID string, acceptance domain.AcceptanceStatus) ([]domain.Audit, error)
+
+	// Temporary links (la URL con el token en claro solo se devuelve al crearlo)
+	CreateTemporaryLink(ctx context.Context, auditID, actorID, orgID, userID string, scopes []domain.LinkScope, ttl time.Duration, maxUses int) (*domain.TemporaryLink, string, error)
+	ListTemporaryLinks(ctx context.Context, auditID, actorID, orgID string) ([]domain.TemporaryLink, error)
+	RevokeTemporaryLink(ctx context.Context, auditID, linkID, actorID, orgID string) error
+
+	// Assignments
+	RespondToAssignment(ctx context.Context, auditID, userID string, accept bool, reason string) (*domain.AuditAssignment, error)
+	ListAssignments(ctx context.Context, auditID, userID string) ([]domain.AuditAssignment, error)
+	ReassignStaff(ctx context.Context, auditID, actorID, declinedUserID, newUserID, orgID string) error
+
+	// Checklist
+	GetChecklist(ctx context.Context, auditID, userID string) ([]domain.ChecklistItem, error)
+	AddChecklistItems(ctx context.Context, auditID, userID string, items []domain.ChecklistItem) ([]domain.ChecklistItem, error)
+	AnswerChecklistItem(ctx context.Context, auditID, itemID, userID string, answer domain.ChecklistAnswer, notes string) (*domain.ChecklistItem, error)
+
+	// Lifecycle
+	TransitionAudit(ctx context.Context, auditID, userID string, target domain.AuditStatus, reason string) (*domain.Audit, error)
+	GetStatusHistory(ctx context.Context, auditID, userID string) ([]domain.AuditStatusChange, error)
+}
+
+type PlanningService interface {
+	GetPlan(ctx context.Context, auditID, userID string) (*domain.AuditPlan, error)
+	SetSchedule(ctx context.Context, auditID, userID string, plannedStart, plannedEnd *time.Time) (*domain.AuditPlan, error)
+	AddSession(ctx context.Context, auditID, userID string, input *domain.AuditSession) (*domain.AuditSession, error)
+	UpdateSession(ctx context.Context, auditID, sessionID, userID string, input *domain.AuditSession) (*domain.AuditSession, error)
+	DeleteSession(ctx context.Context, auditID, sessionID, userID string) error
+	ListConflicts(ctx context.Context, auditID, userID string) ([]domain.ScheduleConflict, error)
+}
+
+type CalendarService interface {
+	EnableFeed(ctx context.Context, userID string) (string, error) // Devuelve el token en claro (solo esta vez)
+	DisableFeed(ctx context.Context, userID string) error
+	Feed(ctx context.Context, token string) ([]domain.CalendarEvent, error)
+}
+
+type ProgrammeService interface {
+	CreateProgramme(ctx context.Context, orgID, userID string, input *domain.AuditProgramme) (*domain.AuditProgramme, error)
+	ListProgrammes(ctx context.Context, orgID, userID string) ([]domain.AuditProgramme, error)
+	GetProgramme(ctx context.Context, orgID, programmeID, userID string) (*domain.AuditProgramme, error)
+	UpdateProgramme(ctx context.Context, orgID, programmeID, userID string, input *domain.AuditProgramme) (*domain.AuditProgramme, error)
+	AddEntry(ctx context.Context, orgID, programmeID, userID string, input *domain.ProgrammeEntry) (*domain.ProgrammeEntry, error)
+	ListEntries(ctx context.Context, orgID, programmeID, userID string) ([]domain.ProgrammeEntry, error)
+	DeleteEntry(ctx context.Context, orgID, programmeID, entryID, userID string) error
+	// SpawnAudit crea la Audit concreta de una entrada vía AuditService.CreateAudit
+	SpawnAudit(ctx context.Context, orgID, programmeID, entryID, userID string) (*domain.Audit, error)
+	Coverage(ctx context.Context, orgID, programmeID, userID string) (*domain.ProgrammeCoverage, error)
+}
+
+type StandardService interface {
+	ListStandards(ctx context.Context, orgID string) ([]domain.Standard, error)
+	GetStandard(ctx context.Context, orgID, standardID string) (*domain.StandardDetail, error)
+	// ImportStandard da de alta una norma privada de orgID; orgID vacío la agrega al catálogo global
+	ImportStandard(ctx context.Context, orgID, userID string, standard *domain.Standard, clauses []domain.Clause) (*domain.StandardDetail, error)
+	DeleteStandard(ctx context.Context, orgID, standardID, userID string) error
+	// BuildChecklist agrega al checklist de la auditoría las cláusulas elegidas (todas si numbers está vacío)
+	BuildChecklist(ctx context.Context, auditID, userID, orgID, standardID string, numbers []string) ([]domain.ChecklistItem, error)
+}
+
+type ChecklistTemplateService interface {
+	ListTemplates(ctx context.Context, orgID string) ([]domain.ChecklistTemplate, error)
+	GetTemplate(ctx context.Context, orgID, templateID string) (*domain.ChecklistTemplateDetail, error)
+	ListVersions(ctx context.Context, orgID, templateID string) ([]domain.ChecklistTemplate, error)
+	CreateTemplate(ctx context.Context, orgID, userID string, input *domain.ChecklistTemplateDetail) (*domain.ChecklistTemplateDetail, error)
+	// UpdateTemplate publica una nueva versión; templateID tiene que ser la última
+	UpdateTemplate(ctx context.Context, orgID, templateID, userID string, input *domain.ChecklistTemplateDetail) (*domain.ChecklistTemplateDetail, error)
+	CloneTemplate(ctx context.Context, orgID, templateID, userID, name string) (*domain.ChecklistTemplateDetail, error)
+}
+
+type FindingService interface {
+	CreateFinding(ctx context.Context, auditID, userID string, input *domain.Finding) (*domain.Finding, error)
+	ListFindings(ctx context.Context, auditID, userID string) ([]domain.Finding, error)
+	GetFinding(ctx context.Context, auditID, findingID, userID string) (*domain.Finding, error)
+	UpdateFinding(ctx context.Context, auditID, findingID, userID string, input *domain.Finding) (*domain.Finding, error)
+	DeleteFinding(ctx context.Context, auditID, findingID, userID string) error
+}
+
+type CAPAService interface {
+	CreateCAPA(ctx context.Context, auditID, findingID, userID, responsibleUserID, responsibleOrgID string, dueDate time.Time) (*domain.CorrectiveAction, error)
+	ListAuditCAPAs(ctx context.Context, auditID, userID string) ([]domain.CorrectiveAction, error)
+	ListOrgCAPAs(ctx context.Context, orgID, userID string) ([]domain.CorrectiveAction, error)
+	GetCAPA(ctx context.Context, capaID, userID, orgID string) (*domain.CorrectiveAction, error)
+	ProposeCAPA(ctx context.Context, capaID, userID, orgID, rootCause, actionPlan string) (*domain.CorrectiveAction, error)
+	TransitionCAPA(ctx context.Context, capaID, userID, orgID string, target domain.CAPAStatus, notes string) (*domain.CorrectiveAction, error)
+}
+
+type EvidenceService interface {
+	UploadEvidence(ctx context.Context, auditID, userID string, meta *domain.Evidence, r io.Reader) (*domain.Evidence, error)
+	ListEvidence(ctx context.Context, auditID, userID string) ([]domain.Evidence, error)
+	DownloadEvidence(ctx context.Context, auditID, evidenceID, userID string) (*domain.Evidence, io.ReadCloser, error)
+	DeleteEvidence(ctx context.Context, auditID, evidenceID, userID string) error
+}
+
+type ReportService interface {
+	GenerateReport(ctx context.Context, auditID, userID, format string) (*domain.ReportFile, error)
+	SetConclusions(ctx context.Context, auditID, userID, conclusions string) error
+	GetTemplate(ctx context.Context, orgID string) (*domain.ReportTemplate, error)
+	SaveTemplate(ctx context.Context, orgID, userID string, tpl *domain.ReportTemplate) (*domain.ReportTemplate, error)
+}
+
+// Notifier envía notificaciones salientes (SMTP, log, no-op)
+type Notifier interface {
+	Notify(n domain.Notification) error
+}
+
+type GuestService interface {
+	OpenLink(ctx context.Context, token string) (*domain.PublicAuditView, error)
+	StartGuestSession(ctx context.Context, token string) (string, *domain.PublicAuditView, error)
+}
+
+type ActivityService interface {
+	ListActivity(ctx context.Context, orgID, userID string, filter domain.ActivityFilter) ([]domain.ActivityEntry, error)
+	VerifyChain(ctx context.Context, orgID, userID string) (*domain.ActivityVerification, error)
+}
+
+// AdminService son las tareas de operador de isoctl: no pasan por la autorización por rol
+type AdminService interface {
+	// CreateOrganization crea la organización con su primer usuario (rol Consultora)
+	CreateOrganization(ctx context.Context, name, email, password string) (*domain.Organization, *domain.User, error)
+	ResetPassword(ctx context.Context, email, password string) error
+	ChangeRole(ctx context.Context, email, orgID string, role domain.Role) error
+	// RevokeTokens cierra todas las sesiones activas del usuario y devuelve cuántas eran
+	RevokeTokens(ctx context.Context, email string) (int, error)
+	PurgeRevokedTokens(ctx context.Context) (int64, error)
+	ListAudits(ctx context.Context, orgID string) ([]domain.Audit, error)
 }
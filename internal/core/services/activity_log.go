@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// ActivityLog escribe la bitácora de cambios desde los servicios, con el solicitante y el
+// ID de petición que traiga el contexto
+type ActivityLog struct {
+	repo ports.ActivityRepository
+}
+
+func NewActivityLog(repo ports.ActivityRepository) *ActivityLog {
+	return &ActivityLog{repo: repo}
+}
+
+// record agrega un registro a la cadena de orgID. before y after son instantáneas de la entidad
+// (nil = alta o baja); solo se guardan los campos que cambian. actorID vacío toma el usuario
+// autenticado del contexto. Un fallo no revierte la operación ya confirmada, pero queda en el log.
+func (l *ActivityLog) record(ctx context.Context, orgID, actorID string, entity domain.ActivityEntity, entityID string, action domain.ActivityAction, before, after interface{}) {
+	if l == nil || l.repo == nil || orgID == "" {
+		return
+	}
+
+	meta := domain.RequestMetaFrom(ctx)
+	if actorID == "" {
+		actorID = meta.ActorID
+	}
+
+	changes, err := activityChanges(before, after)
+	if err != nil {
+		log.Printf("error registrando actividad %s %s/%s: %v", action, entity, entityID, err)
+		return
+	}
+
+	entry := &domain.ActivityEntry{
+		OrganizationID: orgID,
+		ActorID:        actorID,
+		EntityType:     entity,
+		EntityID:       entityID,
+		Action:         action,
+		Changes:        changes,
+		RequestID:      meta.RequestID,
+		IP:             meta.IP,
+	}
+	// La operación ya se confirmó: el registro se escribe aunque la petición se cancele
+	if err := l.repo.AppendActivity(context.WithoutCancel(ctx), entry); err != nil {
+		log.Printf("error registrando actividad %s %s/%s: %v", action, entity, entityID, err)
+	}
+}
+
+// activityChanges compara campo a campo la representación JSON de las dos instantáneas.
+// Los campos con json:"-" (hashes, contraseñas) nunca llegan a la bitácora.
+func activityChanges(before, after interface{}) (domain.JSONText, error) {
+	from, err := activitySnapshot(before)
+	if err != nil {
+		return "", err
+	}
+	to, err := activitySnapshot(after)
+	if err != nil {
+		return "", err
+	}
+
+	changes := map[string]map[string]interface{}{}
+	for field, value := range to {
+		old, existed := from[field]
+		if existed && reflect.DeepEqual(old, value) {
+			continue
+		}
+		change := map[string]interface{}{"to": value}
+		if existed {
+			change["from"] = old
+		}
+		changes[field] = change
+	}
+	for field, old := range from {
+		if _, ok := to[field]; !ok {
+			changes[field] = map[string]interface{}{"from": old}
+		}
+	}
+	delete(changes, "updated_at")
+	if len(changes) == 0 {
+		return "", nil
+	}
+
+	// encoding/json ordena las claves de los mapas: el texto es estable para el hash
+	raw, err := json.Marshal(changes)
+	if err != nil {
+		return "", err
+	}
+	return domain.JSONText(raw), nil
+}
+
+func activitySnapshot(v interface{}) (map[string]interface{}, error) {
+	if v == nil || reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil() {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+const (
+	defaultActivityLimit = 100
+	maxActivityLimit     = 500
+	activityVerifyBatch  = 1000
+)
+
+// ActivityService consulta la bitácora de la organización y verifica su cadena de hashes
+type ActivityService struct {
+	repo    ports.ActivityRepository
+	orgRepo ports.OrganizationRepository
+}
+
+func NewActivityService(repo ports.ActivityRepository, orgRepo ports.OrganizationRepository) *ActivityService {
+	return &ActivityService{
+		repo:    repo,
+		orgRepo: orgRepo,
+	}
+}
+
+func (s *ActivityService) ListActivity(ctx context.Context, orgID, userID string, filter domain.ActivityFilter) ([]domain.ActivityEntry, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionActivityRead); err != nil {
+		return nil, err
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultActivityLimit
+	}
+	if filter.Limit > maxActivityLimit {
+		filter.Limit = maxActivityLimit
+	}
+	return s.repo.ListActivity(ctx, orgID, filter)
+}
+
+// VerifyChain recalcula la cadena completa: detecta registros alterados, borrados o intercalados
+func (s *ActivityService) VerifyChain(ctx context.Context, orgID, userID string) (*domain.ActivityVerification, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionActivityRead); err != nil {
+		return nil, err
+	}
+
+	result := &domain.ActivityVerification{Valid: true}
+	var prevSeq int64
+	prevHash := ""
+	for {
+		entries, err := s.repo.ListActivityChain(ctx, orgID, prevSeq, activityVerifyBatch)
+		if err != nil {
+			return nil, err
+		}
+		for i := range entries {
+			entry := &entries[i]
+			if entry.Seq != prevSeq+1 || entry.PrevHash != prevHash || entry.ComputeHash() != entry.Hash {
+				result.Valid = false
+				result.BrokenAtSeq = entry.Seq
+				return result, nil
+			}
+			result.Checked++
+			prevSeq, prevHash = entry.Seq, entry.Hash
+		}
+		if len(entries) < activityVerifyBatch {
+			return result, nil
+		}
+	}
+}
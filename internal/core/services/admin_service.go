@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AdminService agrupa las tareas de operador (isoctl). Quien lo usa ya tiene acceso a la
+// base, así que no hay autorización por rol; los cambios igual quedan en la bitácora.
+type AdminService struct {
+	authRepo  ports.AuthRepository
+	orgRepo   ports.OrganizationRepository
+	repo      ports.AdminRepository
+	accessTTL time.Duration
+	activity  *ActivityLog
+}
+
+func NewAdminService(authRepo ports.AuthRepository, orgRepo ports.OrganizationRepository, repo ports.AdminRepository, accessTTL time.Duration, activity *ActivityLog) *AdminService {
+	return &AdminService{
+		authRepo:  authRepo,
+		orgRepo:   orgRepo,
+		repo:      repo,
+		accessTTL: accessTTL,
+		activity:  activity,
+	}
+}
+
+func (s *AdminService) CreateOrganization(ctx context.Context, name, email, password string) (*domain.Organization, *domain.User, error) {
+	name, email = strings.TrimSpace(name), strings.TrimSpace(email)
+	if name == "" || email == "" {
+		return nil, nil, fmt.Errorf("%w: name and email are required", domain.ErrInvalidInput)
+	}
+	hashedPassword, err := hashPassword(password)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := s.authRepo.FindUserByEmail(ctx, email); err == nil {
+		return nil, nil, fmt.Errorf("%w: user %s already exists", domain.ErrInvalidInput, email)
+	}
+
+	org := &domain.Organization{Name: name}
+	user := &domain.User{Email: email, Password: hashedPassword}
+	userOrg := &domain.UserOrganization{
+		RoleDefault: domain.RoleConsultora,
+		Status:      domain.MemberActivo,
+		JoinedAt:    time.Now(),
+	}
+	if err := s.authRepo.CreateUserWithOrg(ctx, user, org, userOrg); err != nil {
+		return nil, nil, err
+	}
+	s.activity.record(ctx, org.ID, "", domain.EntityOrganization, org.ID, domain.ActivityCreate, nil, org)
+	s.activity.record(ctx, org.ID, "", domain.EntityUser, user.ID, domain.ActivityCreate, nil, user)
+	return org, user, nil
+}
+
+// ResetPassword cambia la contraseña y cierra las sesiones abiertas con la anterior
+func (s *AdminService) ResetPassword(ctx context.Context, email, password string) error {
+	user, err := s.authRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		return domain.ErrNotFound
+	}
+	hashedPassword, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdateUserPassword(ctx, user.ID, hashedPassword); err != nil {
+		return err
+	}
+	if primary, err := s.authRepo.GetUserPrimaryOrg(ctx, user.ID); err == nil {
+		s.activity.record(ctx, primary.OrganizationID, "", domain.EntityUser, user.ID, domain.ActivityUpdate, nil, map[string]string{"password": "reset"})
+	}
+	_, err = s.revokeSessions(ctx, user.ID)
+	return err
+}
+
+// ChangeRole cambia el rol del usuario en orgID. Los tokens emitidos llevan el rol anterior,
+// por eso se cierran sus sesiones.
+func (s *AdminService) ChangeRole(ctx context.Context, email, orgID string, role domain.Role) error {
+	if !role.IsValid() {
+		return fmt.Errorf("%w: unknown role %q", domain.ErrInvalidInput, role)
+	}
+	user, err := s.authRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		return domain.ErrNotFound
+	}
+	before, err := s.orgRepo.FindUserOrg(ctx, user.ID, orgID)
+	if err != nil {
+		return domain.ErrNotFound
+	}
+	if err := s.repo.UpdateMemberRole(ctx, user.ID, orgID, role); err != nil {
+		return err
+	}
+	after := *before
+	after.RoleDefault = role
+	s.activity.record(ctx, orgID, "", domain.EntityMembership, user.ID, domain.ActivityUpdate, before, &after)
+
+	_, err = s.revokeSessions(ctx, user.ID)
+	return err
+}
+
+func (s *AdminService) RevokeTokens(ctx context.Context, email string) (int, error) {
+	user, err := s.authRepo.FindUserByEmail(ctx, email)
+	if err != nil {
+		return 0, domain.ErrNotFound
+	}
+	return s.revokeSessions(ctx, user.ID)
+}
+
+// PurgeRevokedTokens borra los jti revocados que ya no hace falta recordar: su access token venció
+func (s *AdminService) PurgeRevokedTokens(ctx context.Context) (int64, error) {
+	return s.repo.PurgeExpiredRevokedTokens(ctx, time.Now())
+}
+
+func (s *AdminService) ListAudits(ctx context.Context, orgID string) ([]domain.Audit, error) {
+	if _, err := s.orgRepo.FindOrganization(ctx, orgID); err != nil {
+		return nil, domain.ErrNotFound
+	}
+	return s.repo.ListOrgAudits(ctx, orgID)
+}
+
+// revokeSessions hace lo mismo que Logout con cada sesión activa del usuario
+func (s *AdminService) revokeSessions(ctx context.Context, userID string) (int, error) {
+	sessions, err := s.authRepo.ListActiveSessions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	for i, session := range sessions {
+		if err := s.authRepo.RevokeSession(ctx, session.ID); err != nil {
+			return i, err
+		}
+		if err := s.authRepo.RevokeToken(ctx, session.ID, time.Now().Add(s.accessTTL)); err != nil {
+			return i, err
+		}
+		s.activity.record(ctx, session.OrganizationID, "", domain.EntitySession, session.ID, domain.ActivityRevoke, nil, nil)
+	}
+	return len(sessions), nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < 8 {
+		return "", fmt.Errorf("%w: password must have at least 8 characters", domain.ErrInvalidInput)
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), 12)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
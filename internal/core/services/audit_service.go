@@ -1,35 +1,98 @@
 package services
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/RiosHectorM/iso-stack/internal/core/domain"
 	"github.com/RiosHectorM/iso-stack/internal/core/ports"
-	"github.com/google/uuid"
 )
 
+// auditTransitions es la tabla explícita de transiciones permitidas del ciclo de vida
+var auditTransitions = map[domain.AuditStatus][]domain.AuditStatus{
+	domain.AuditPlanificada: {domain.AuditEnCurso},
+	domain.AuditEnCurso:     {domain.AuditPausada, domain.AuditFinalizada},
+	domain.AuditPausada:     {domain.AuditEnCurso},
+}
+
+func canTransition(from, to domain.AuditStatus) bool {
+	for _, allowed := range auditTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultLinkScopes: permisos del enlace temporal que se genera al asignar a un externo
+var defaultLinkScopes = map[domain.Role][]domain.LinkScope{
+	domain.RoleAuxiliar:   {domain.ScopeView, domain.ScopeAnswerChecklist, domain.ScopeUploadEvidence},
+	domain.RoleObservador: {domain.ScopeView},
+}
+
 type AuditService struct {
-	repo    ports.AuditRepository
-	orgRepo ports.OrganizationRepository
+	repo        ports.AuditRepository
+	orgRepo     ports.OrganizationRepository
+	authRepo    ports.AuthRepository // Emails de destinatarios de notificaciones
+	clientRepo  ports.ClientRepository
+	tplRepo     ports.ChecklistTemplateRepository
+	mailer      *Mailer
+	tokenSecret string
+	linkTTL     time.Duration
+	activity    *ActivityLog
 }
 
-func NewAuditService(repo ports.AuditRepository, orgRepo ports.OrganizationRepository) *AuditService {
+func NewAuditService(repo ports.AuditRepository, orgRepo ports.OrganizationRepository, authRepo ports.AuthRepository, clientRepo ports.ClientRepository, tplRepo ports.ChecklistTemplateRepository, mailer *Mailer, tokenSecret string, linkTTL time.Duration, activity *ActivityLog) *AuditService {
 	return &AuditService{
-		repo:    repo,
-		orgRepo: orgRepo,
+		repo:        repo,
+		orgRepo:     orgRepo,
+		authRepo:    authRepo,
+		clientRepo:  clientRepo,
+		tplRepo:     tplRepo,
+		mailer:      mailer,
+		tokenSecret: tokenSecret,
+		linkTTL:     linkTTL,
+		activity:    activity,
 	}
 }
 
-func (s *AuditService) CreateAudit(title, orgOwnerID, userID string) (*domain.Audit, error) {
+func (s *AuditService) CreateAudit(ctx context.Context, title, orgOwnerID, userID, clientID, templateID string) (*domain.Audit, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgOwnerID, domain.ActionAuditCreate); err != nil {
+		return nil, err
+	}
+
 	audit := &domain.Audit{
 		Title:      title,
 		OrgOwnerID: orgOwnerID,
 		Status:     domain.AuditPlanificada,
 	}
+	if clientID != "" {
+		client, err := s.clientRepo.FindClient(ctx, orgOwnerID, clientID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: client does not belong to the organization", domain.ErrInvalidInput)
+		}
+		audit.AuditeeOrgID = client.ClientOrgID
+	}
+
+	// La plantilla se valida antes de crear nada: tiene que ser de la misma organización
+	var templateItems []domain.ChecklistTemplateItem
+	if templateID != "" {
+		tpl, err := s.tplRepo.FindChecklistTemplate(ctx, orgOwnerID, templateID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: checklist template does not belong to the organization", domain.ErrInvalidInput)
+		}
+		if templateItems, err = s.tplRepo.ListChecklistTemplateItems(ctx, tpl.ID); err != nil {
+			return nil, err
+		}
+		audit.ChecklistTemplateID = tpl.ID
+	}
 
-	if err := s.repo.CreateAudit(audit); err != nil {
+	if err := s.repo.CreateAudit(ctx, audit); err != nil {
 		return nil, err
 	}
+	s.activity.record(ctx, orgOwnerID, userID, domain.EntityAudit, audit.ID, domain.ActivityCreate, nil, audit)
 
 	// Auto-assign Creator as Auditor_Lider
 	assignment := &domain.AuditAssignment{
@@ -39,40 +102,449 @@ func (s *AuditService) CreateAudit(title, orgOwnerID, userID string) (*domain.Au
 		AcceptanceStatus: domain.AcceptAceptado,
 		IsActive:         true,
 	}
-	if err := s.repo.AssignUserToAudit(assignment); err != nil {
+	if err := s.repo.AssignUserToAudit(ctx, assignment); err != nil {
 		return nil, err
 	}
+	s.recordAssignment(ctx, orgOwnerID, userID, domain.ActivityCreate, nil, assignment)
+
+	if len(templateItems) > 0 {
+		items := make([]domain.ChecklistItem, len(templateItems))
+		for i, t := range templateItems {
+			items[i] = domain.ChecklistItem{
+				AuditID:          audit.ID,
+				Standard:         t.Standard,
+				ClauseNumber:     t.ClauseNumber,
+				Requirement:      t.Requirement,
+				ExpectedEvidence: t.ExpectedEvidence,
+				ClauseID:         t.ClauseID,
+				Answer:           domain.AnswerPendiente,
+			}
+		}
+		if err := s.repo.CreateChecklistItems(ctx, items); err != nil {
+			return nil, err
+		}
+		for i := range items {
+			s.activity.record(ctx, orgOwnerID, userID, domain.EntityChecklistItem, items[i].ID, domain.ActivityCreate, nil, &items[i])
+		}
+	}
 
 	return audit, nil
 }
 
-func (s *AuditService) AssignStaff(auditID, userID, role, orgID string) error {
-	// 1. Verify User belongs to Organization
-	if _, err := s.orgRepo.FindUserOrg(userID, orgID); err != nil {
-		return errors.New("user does not belong to your organization")
+func (s *AuditService) AssignStaff(ctx context.Context, auditID, actorID, userID, role, orgID string) error {
+	// 0. The audit must belong to the caller's organization, and the caller must be allowed
+	// to staff it: by org role (Consultora...) or as Auditor_Lider of this audit
+	audit, err := s.authorizeStaffing(ctx, auditID, actorID, orgID)
+	if err != nil {
+		return err
+	}
+	// 1. Validate role, membership and that the user is not already on the team
+	assignment, err := s.newAssignment(ctx, auditID, userID, domain.Role(role), orgID)
+	if err != nil {
+		return err
 	}
 
-	// 2. Create Assignment
-	assignment := &domain.AuditAssignment{
+	// 2. Create Assignment (an inactive one for the same user is reactivated)
+	if err := s.repo.AssignUserToAudit(ctx, assignment); err != nil {
+		return err
+	}
+	return s.announceAssignment(ctx, audit, actorID, orgID, assignment)
+}
+
+// newAssignment valida al usuario antes de tocar el equipo: rol conocido, miembro de la
+// organización y sin una asignación activa en la auditoría
+func (s *AuditService) newAssignment(ctx context.Context, auditID, userID string, role domain.Role, orgID string) (*domain.AuditAssignment, error) {
+	if !role.IsValid() {
+		return nil, fmt.Errorf("%w: unknown role %q", domain.ErrInvalidInput, role)
+	}
+	if _, err := s.orgRepo.FindUserOrg(ctx, userID, orgID); err != nil {
+		return nil, fmt.Errorf("%w: user does not belong to your organization", domain.ErrInvalidInput)
+	}
+	if existing, err := s.repo.FindAuditAssignment(ctx, auditID, userID); err == nil && existing.IsActive {
+		return nil, fmt.Errorf("%w: user is already assigned to this audit", domain.ErrConflict)
+	}
+	return &domain.AuditAssignment{
 		AuditID:          auditID,
 		UserID:           userID,
-		RoleInAudit:      domain.Role(role),
+		RoleInAudit:      role,
 		AcceptanceStatus: domain.AcceptPendiente,
 		IsActive:         true,
+	}, nil
+}
+
+// announceAssignment registra la asignación ya guardada, emite el enlace de los roles
+// externos y avisa al usuario asignado
+func (s *AuditService) announceAssignment(ctx context.Context, audit *domain.Audit, actorID, orgID string, assignment *domain.AuditAssignment) error {
+	s.recordAssignment(ctx, orgID, actorID, domain.ActivityCreate, nil, assignment)
+
+	data := map[string]string{
+		"audit_title": audit.Title,
+		"role":        string(assignment.RoleInAudit),
+	}
+
+	// Generate Temporary Link for External Roles
+	if scopes, ok := defaultLinkScopes[assignment.RoleInAudit]; ok {
+		_, url, err := s.issueTemporaryLink(ctx, audit, assignment.UserID, actorID, scopes, s.linkTTL, 0)
+		if err != nil {
+			return err
+		}
+		data["temp_link_url"] = url
+	}
+
+	s.notifyUser(ctx, assignment.UserID, domain.NotifyAuditAssignment, data)
+	return nil
+}
+
+// authorizeStaffing: la auditoría debe pertenecer a la organización del actor, y el actor
+// debe poder asignar personal por su rol en la organización o en la propia auditoría
+func (s *AuditService) authorizeStaffing(ctx context.Context, auditID, actorID, orgID string) (*domain.Audit, error) {
+	audit, err := s.repo.GetAuditByID(ctx, auditID)
+	if err != nil || audit.OrgOwnerID != orgID {
+		return nil, domain.ErrNotFound
+	}
+	if err := authorizeOrg(ctx, s.orgRepo, actorID, orgID, domain.ActionAuditAssign); err != nil {
+		if _, auditErr := authorizeAudit(ctx, s.repo, auditID, actorID, domain.ActionAuditAssign); auditErr != nil {
+			return nil, err
+		}
+	}
+	return audit, nil
+}
+
+// recordAssignment registra el cambio de una asignación; su ID es el par auditoría/usuario
+func (s *AuditService) recordAssignment(ctx context.Context, orgID, actorID string, action domain.ActivityAction, before, after *domain.AuditAssignment) {
+	s.activity.record(ctx, orgID, actorID, domain.EntityAssignment, after.AuditID+"/"+after.UserID, action, before, after)
+}
+
+func (s *AuditService) notifyUser(ctx context.Context, userID string, kind domain.NotificationKind, data map[string]string) {
+	user, err := s.authRepo.FindUserByID(ctx, userID)
+	if err != nil {
+		return
+	}
+	s.mailer.send(user.Email, kind, data)
+}
+
+func (s *AuditService) GetMyAudits(ctx context.Context, userID string, acceptance domain.AcceptanceStatus) ([]domain.Audit, error) {
+	return s.repo.GetAuditsByUserID(ctx, userID, acceptance)
+}
+
+// --- Temporary links ---
+
+func (s *AuditService) CreateTemporaryLink(ctx context.Context, auditID, actorID, orgID, userID string, scopes []domain.LinkScope, ttl time.Duration, maxUses int) (*domain.TemporaryLink, string, error) {
+	audit, err := s.authorizeStaffing(ctx, auditID, actorID, orgID)
+	if err != nil {
+		return nil, "", err
+	}
+	assignment, err := s.repo.FindAuditAssignment(ctx, auditID, userID)
+	if err != nil || !assignment.IsActive {
+		return nil, "", fmt.Errorf("%w: user is not assigned to this audit", domain.ErrInvalidInput)
+	}
+
+	if len(scopes) == 0 {
+		scopes = defaultLinkScopes[assignment.RoleInAudit]
+	}
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("%w: at least one scope is required", domain.ErrInvalidInput)
+	}
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, "", fmt.Errorf("%w: unknown scope %q", domain.ErrInvalidInput, scope)
+		}
+	}
+	if ttl <= 0 {
+		ttl = s.linkTTL
+	}
+	if maxUses < 0 {
+		return nil, "", fmt.Errorf("%w: max_uses cannot be negative", domain.ErrInvalidInput)
+	}
+
+	return s.issueTemporaryLink(ctx, audit, userID, actorID, scopes, ttl, maxUses)
+}
+
+func (s *AuditService) ListTemporaryLinks(ctx context.Context, auditID, actorID, orgID string) ([]domain.TemporaryLink, error) {
+	if _, err := s.authorizeStaffing(ctx, auditID, actorID, orgID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListTemporaryLinks(ctx, auditID)
+}
+
+func (s *AuditService) RevokeTemporaryLink(ctx context.Context, auditID, linkID, actorID, orgID string) error {
+	if _, err := s.authorizeStaffing(ctx, auditID, actorID, orgID); err != nil {
+		return err
+	}
+	if err := s.repo.RevokeTemporaryLink(ctx, auditID, linkID); err != nil {
+		return err
+	}
+	s.activity.record(ctx, orgID, actorID, domain.EntityTemporaryLink, linkID, domain.ActivityRevoke, nil, nil)
+	return nil
+}
+
+// issueTemporaryLink persiste el hash del token y devuelve la URL pública con el token en claro
+func (s *AuditService) issueTemporaryLink(ctx context.Context, audit *domain.Audit, userID, createdBy string, scopes []domain.LinkScope, ttl time.Duration, maxUses int) (*domain.TemporaryLink, string, error) {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	link := &domain.TemporaryLink{
+		AuditID:   audit.ID,
+		UserID:    userID,
+		TokenHash: hashToken(s.tokenSecret, token),
+		Scopes:    strings.Join(names, ","),
+		ExpiresAt: time.Now().Add(ttl),
+		MaxUses:   maxUses,
+		CreatedBy: createdBy,
+	}
+	if err := s.repo.CreateTemporaryLink(ctx, link); err != nil {
+		return nil, "", err
+	}
+	s.activity.record(ctx, audit.OrgOwnerID, createdBy, domain.EntityTemporaryLink, link.ID, domain.ActivityCreate, nil, link)
+	return link, s.mailer.link("/public/access/" + token), nil
+}
+
+// --- Assignments ---
+
+// RespondToAssignment registra la aceptación o el rechazo del usuario asignado
+func (s *AuditService) RespondToAssignment(ctx context.Context, auditID, userID string, accept bool, reason string) (*domain.AuditAssignment, error) {
+	assignment, err := s.repo.FindAuditAssignment(ctx, auditID, userID)
+	if err != nil || !assignment.IsActive {
+		return nil, domain.ErrNotAssigned
+	}
+	if assignment.AcceptanceStatus != domain.AcceptPendiente {
+		return nil, fmt.Errorf("%w: assignment was already %s", domain.ErrConflict, assignment.AcceptanceStatus)
+	}
+	if !accept && reason == "" {
+		return nil, fmt.Errorf("%w: a reason is required to reject an assignment", domain.ErrInvalidInput)
+	}
+	audit, err := s.repo.GetAuditByID(ctx, auditID)
+	if err != nil {
+		return nil, domain.ErrNotFound
 	}
 
-	// 3. Generate Temporary Link for External Roles
-	if role == string(domain.RoleAuxiliar) || role == string(domain.RoleObservador) {
-		assignment.TemporaryLink = uuid.New().String()
+	before := *assignment
+	now := time.Now()
+	assignment.RespondedAt = &now
+	if accept {
+		assignment.AcceptanceStatus = domain.AcceptAceptado
+	} else {
+		assignment.AcceptanceStatus = domain.AcceptRechazado
+		assignment.RejectionReason = reason
 	}
 
-	return s.repo.AssignUserToAudit(assignment)
+	if err := s.repo.UpdateAuditAssignment(ctx, assignment); err != nil {
+		return nil, err
+	}
+	s.recordAssignment(ctx, audit.OrgOwnerID, userID, domain.ActivityUpdate, &before, assignment)
+	s.notifyLeads(ctx, audit, assignment)
+	return assignment, nil
 }
 
-func (s *AuditService) GetMyAudits(userID string) ([]domain.Audit, error) {
-	return s.repo.GetAuditsByUserID(userID)
+// notifyLeads avisa a los auditores líderes activos de la respuesta a una asignación
+func (s *AuditService) notifyLeads(ctx context.Context, audit *domain.Audit, assignment *domain.AuditAssignment) {
+	team, err := s.repo.ListAuditAssignments(ctx, audit.ID)
+	if err != nil {
+		return
+	}
+	user, err := s.authRepo.FindUserByID(ctx, assignment.UserID)
+	if err != nil {
+		return
+	}
+	data := map[string]string{
+		"audit_title": audit.Title,
+		"user":        user.Email,
+		"role":        string(assignment.RoleInAudit),
+		"response":    string(assignment.AcceptanceStatus),
+		"reason":      assignment.RejectionReason,
+	}
+	for _, member := range team {
+		if member.IsActive && member.RoleInAudit == domain.RoleAuditorLider && member.UserID != assignment.UserID {
+			s.notifyUser(ctx, member.UserID, domain.NotifyAssignmentReply, data)
+		}
+	}
 }
 
-func (s *AuditService) GetPublicAudit(tempLink string) (*domain.Audit, error) {
-	return s.repo.GetAuditByTempLink(tempLink)
+// ListAssignments muestra al equipo con el estado de respuesta de cada integrante
+func (s *AuditService) ListAssignments(ctx context.Context, auditID, userID string) ([]domain.AuditAssignment, error) {
+	if _, err := authorizeAudit(ctx, s.repo, auditID, userID, domain.ActionAuditRead); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAuditAssignments(ctx, auditID)
+}
+
+// ReassignStaff cubre un lugar rechazado: desactiva la asignación rechazada y asigna
+// el mismo rol a otro usuario (o vuelve a proponérselo al mismo)
+func (s *AuditService) ReassignStaff(ctx context.Context, auditID, actorID, declinedUserID, newUserID, orgID string) error {
+	audit, err := s.authorizeStaffing(ctx, auditID, actorID, orgID)
+	if err != nil {
+		return err
+	}
+
+	declined, err := s.repo.FindAuditAssignment(ctx, auditID, declinedUserID)
+	if err != nil || !declined.IsActive {
+		return domain.ErrNotFound
+	}
+	if declined.AcceptanceStatus != domain.AcceptRechazado {
+		return fmt.Errorf("%w: only rejected assignments can be reassigned", domain.ErrConflict)
+	}
+
+	before := *declined
+	if newUserID == declinedUserID {
+		declined.AcceptanceStatus = domain.AcceptPendiente
+		declined.RejectionReason = ""
+		declined.RespondedAt = nil
+		if err := s.repo.UpdateAuditAssignment(ctx, declined); err != nil {
+			return err
+		}
+		s.recordAssignment(ctx, orgID, actorID, domain.ActivityUpdate, &before, declined)
+		return nil
+	}
+
+	// El reemplazo se valida antes y se guarda junto con la baja: si falla, el lugar sigue rechazado
+	replacement, err := s.newAssignment(ctx, auditID, newUserID, declined.RoleInAudit, orgID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.ReplaceAuditAssignment(ctx, declined, replacement); err != nil {
+		return err
+	}
+	s.recordAssignment(ctx, orgID, actorID, domain.ActivityUpdate, &before, declined)
+	return s.announceAssignment(ctx, audit, actorID, orgID, replacement)
+}
+
+// --- Checklist ---
+
+func (s *AuditService) GetChecklist(ctx context.Context, auditID, userID string) ([]domain.ChecklistItem, error) {
+	if _, err := authorizeAudit(ctx, s.repo, auditID, userID, domain.ActionAuditRead); err != nil {
+		return nil, err
+	}
+	return s.repo.GetChecklistByAuditID(ctx, auditID)
+}
+
+func (s *AuditService) AddChecklistItems(ctx context.Context, auditID, userID string, items []domain.ChecklistItem) ([]domain.ChecklistItem, error) {
+	if _, err := authorizeAudit(ctx, s.repo, auditID, userID, domain.ActionChecklistEdit); err != nil {
+		return nil, err
+	}
+	audit, err := s.repo.GetAuditByID(ctx, auditID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	for i := range items {
+		if items[i].Standard == "" || items[i].ClauseNumber == "" || items[i].Requirement == "" {
+			return nil, fmt.Errorf("%w: standard, clause_number and requirement are required", domain.ErrInvalidInput)
+		}
+		// El cliente no puede precargar respuestas ni IDs
+		items[i].ID = ""
+		items[i].AuditID = auditID
+		items[i].Answer = domain.AnswerPendiente
+		items[i].AnsweredBy = ""
+		items[i].AnsweredAt = nil
+	}
+
+	if err := s.repo.CreateChecklistItems(ctx, items); err != nil {
+		return nil, err
+	}
+	for i := range items {
+		s.activity.record(ctx, audit.OrgOwnerID, userID, domain.EntityChecklistItem, items[i].ID, domain.ActivityCreate, nil, &items[i])
+	}
+	return items, nil
+}
+
+func (s *AuditService) AnswerChecklistItem(ctx context.Context, auditID, itemID, userID string, answer domain.ChecklistAnswer, notes string) (*domain.ChecklistItem, error) {
+	if !answer.IsValid() {
+		return nil, fmt.Errorf("%w: answer must be Conforme, No_Conforme, Observación or No_Aplica", domain.ErrInvalidInput)
+	}
+
+	if _, err := authorizeAudit(ctx, s.repo, auditID, userID, domain.ActionChecklistAnswer); err != nil {
+		return nil, err
+	}
+	audit, err := s.repo.GetAuditByID(ctx, auditID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	item, err := s.repo.FindChecklistItem(ctx, auditID, itemID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	before := *item
+	now := time.Now()
+	item.Answer = answer
+	item.Notes = notes
+	item.AnsweredBy = userID
+	item.AnsweredAt = &now
+
+	if err := s.repo.UpdateChecklistItem(ctx, item); err != nil {
+		return nil, err
+	}
+	s.activity.record(ctx, audit.OrgOwnerID, userID, domain.EntityChecklistItem, item.ID, domain.ActivityUpdate, &before, item)
+	return item, nil
+}
+
+// --- Lifecycle ---
+
+func (s *AuditService) TransitionAudit(ctx context.Context, auditID, userID string, target domain.AuditStatus, reason string) (*domain.Audit, error) {
+	// Solo el equipo auditor mueve el ciclo de vida; solo el líder lo cierra
+	action := domain.ActionAuditTransition
+	if target == domain.AuditFinalizada {
+		action = domain.ActionAuditFinalize
+	}
+	if _, err := authorizeAudit(ctx, s.repo, auditID, userID, action); err != nil {
+		return nil, err
+	}
+
+	audit, err := s.repo.GetAuditByID(ctx, auditID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	if !canTransition(audit.Status, target) {
+		return nil, fmt.Errorf("%w: cannot move audit from %s to %s", domain.ErrInvalidInput, audit.Status, target)
+	}
+	if target == domain.AuditPausada && reason == "" {
+		return nil, fmt.Errorf("%w: a reason is required to pause an audit", domain.ErrInvalidInput)
+	}
+
+	change := &domain.AuditStatusChange{
+		AuditID:    auditID,
+		FromStatus: audit.Status,
+		ToStatus:   target,
+		ChangedBy:  userID,
+		Reason:     reason,
+	}
+	if err := s.repo.UpdateAuditStatus(ctx, change); err != nil {
+		return nil, err
+	}
+	s.activity.record(ctx, audit.OrgOwnerID, userID, domain.EntityAudit, audit.ID, domain.ActivityTransition,
+		map[string]interface{}{"status": change.FromStatus},
+		map[string]interface{}{"status": change.ToStatus, "reason": reason})
+
+	// Avisar al resto del equipo del cambio de estado
+	if team, err := s.repo.ListAuditAssignments(ctx, auditID); err == nil {
+		data := map[string]string{
+			"audit_title": audit.Title,
+			"from_status": string(audit.Status),
+			"status":      string(target),
+			"reason":      reason,
+		}
+		for _, member := range team {
+			if member.IsActive && member.UserID != userID {
+				s.notifyUser(ctx, member.UserID, domain.NotifyAuditStatus, data)
+			}
+		}
+	}
+
+	audit.Status = target
+	return audit, nil
+}
+
+func (s *AuditService) GetStatusHistory(ctx context.Context, auditID, userID string) ([]domain.AuditStatusChange, error) {
+	if _, err := authorizeAudit(ctx, s.repo, auditID, userID, domain.ActionAuditRead); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAuditStatusHistory(ctx, auditID)
 }
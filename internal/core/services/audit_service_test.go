@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/repository"
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+func TestReassignStaff(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+	audits := NewAuditService(repo, repo, repo, nil, nil, nil, "test-secret", time.Hour, nil)
+
+	owner := &domain.User{Email: "owner@example.com", Password: "x"}
+	org := &domain.Organization{Name: "Consultora"}
+	if err := repo.CreateUserWithOrg(ctx, owner, org, &domain.UserOrganization{RoleDefault: domain.RoleConsultora, JoinedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	staff := map[string]string{}
+	for _, email := range []string{"declined@example.com", "busy@example.com", "new@example.com"} {
+		user := &domain.User{Email: email, Password: "x"}
+		if err := repo.CreateUserAndAddToOrg(ctx, user, &domain.UserOrganization{OrganizationID: org.ID, RoleDefault: domain.RoleAuxiliar, Status: domain.MemberActivo, JoinedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+		staff[email] = user.ID
+	}
+	audit := &domain.Audit{Title: "Auditoría", OrgOwnerID: org.ID, Status: domain.AuditPlanificada}
+	if err := repo.CreateAudit(ctx, audit); err != nil {
+		t.Fatal(err)
+	}
+	declinedID := staff["declined@example.com"]
+	for _, a := range []*domain.AuditAssignment{
+		{AuditID: audit.ID, UserID: declinedID, RoleInAudit: domain.RoleAuxiliar, AcceptanceStatus: domain.AcceptRechazado, RejectionReason: "Vacaciones"},
+		{AuditID: audit.ID, UserID: staff["busy@example.com"], RoleInAudit: domain.RoleObservador},
+	} {
+		if err := repo.AssignUserToAudit(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Un reemplazo inválido no puede hacer perder el lugar rechazado
+	for _, c := range []struct {
+		newUserID string
+		want      error
+	}{
+		{"no-such-user", domain.ErrInvalidInput},
+		{staff["busy@example.com"], domain.ErrConflict},
+	} {
+		if err := audits.ReassignStaff(ctx, audit.ID, owner.ID, declinedID, c.newUserID, org.ID); !errors.Is(err, c.want) {
+			t.Fatalf("reassign to %s: got %v, want %v", c.newUserID, err, c.want)
+		}
+		declined, err := repo.FindAuditAssignment(ctx, audit.ID, declinedID)
+		if err != nil || !declined.IsActive || declined.AcceptanceStatus != domain.AcceptRechazado {
+			t.Fatalf("reassign to %s lost the rejected slot: %+v, %v", c.newUserID, declined, err)
+		}
+	}
+
+	newID := staff["new@example.com"]
+	if err := audits.ReassignStaff(ctx, audit.ID, owner.ID, declinedID, newID, org.ID); err != nil {
+		t.Fatalf("ReassignStaff: %v", err)
+	}
+	replacement, err := repo.FindAuditAssignment(ctx, audit.ID, newID)
+	if err != nil || !replacement.IsActive || replacement.RoleInAudit != domain.RoleAuxiliar || replacement.AcceptanceStatus != domain.AcceptPendiente {
+		t.Fatalf("replacement not assigned: %+v, %v", replacement, err)
+	}
+
+	// Quien rechazó puede volver a ser asignado: su asignación inactiva se reactiva
+	if err := audits.AssignStaff(ctx, audit.ID, owner.ID, declinedID, string(domain.RoleObservador), org.ID); err != nil {
+		t.Fatalf("AssignStaff after reassignment: %v", err)
+	}
+	back, err := repo.FindAuditAssignment(ctx, audit.ID, declinedID)
+	if err != nil || !back.IsActive || back.AcceptanceStatus != domain.AcceptPendiente || back.RejectionReason != "" {
+		t.Fatalf("assignment not reactivated: %+v, %v", back, err)
+	}
+	if err := audits.AssignStaff(ctx, audit.ID, owner.ID, declinedID, string(domain.RoleObservador), org.ID); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("assigning an active member twice: got %v, want ErrConflict", err)
+	}
+}
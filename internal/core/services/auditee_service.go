@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// AuditeeService expone a los usuarios de una organización auditada las auditorías
+// realizadas sobre ella. El aislamiento lo garantizan las consultas del repositorio,
+// que siempre filtran por auditee_org_id = orgID.
+type AuditeeService struct {
+	auditRepo   ports.AuditRepository
+	findingRepo ports.FindingRepository
+	orgRepo     ports.OrganizationRepository
+}
+
+func NewAuditeeService(auditRepo ports.AuditRepository, findingRepo ports.FindingRepository, orgRepo ports.OrganizationRepository) *AuditeeService {
+	return &AuditeeService{
+		auditRepo:   auditRepo,
+		findingRepo: findingRepo,
+		orgRepo:     orgRepo,
+	}
+}
+
+func (s *AuditeeService) ListAudits(ctx context.Context, orgID, userID string) ([]domain.Audit, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionAuditeeRead); err != nil {
+		return nil, err
+	}
+	return s.auditRepo.ListAuditsForAuditee(ctx, orgID)
+}
+
+func (s *AuditeeService) GetAudit(ctx context.Context, orgID, auditID, userID string) (*domain.Audit, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionAuditeeRead); err != nil {
+		return nil, err
+	}
+	audit, err := s.auditRepo.GetAuditForAuditee(ctx, orgID, auditID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	return audit, nil
+}
+
+func (s *AuditeeService) ListFindings(ctx context.Context, orgID, auditID, userID string) ([]domain.Finding, error) {
+	if _, err := s.GetAudit(ctx, orgID, auditID, userID); err != nil {
+		return nil, err
+	}
+	return s.findingRepo.ListFindingsForAuditee(ctx, orgID, auditID)
+}
@@ -1,36 +1,43 @@
 package services
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/RiosHectorM/iso-stack/internal/adapters/auth"
 	"github.com/RiosHectorM/iso-stack/internal/core/domain"
 	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService struct {
 	repo       ports.AuthRepository
 	jwtAdapter *auth.JWTAdapter
+	refreshTTL time.Duration
+	activity   *ActivityLog
 }
 
-func NewAuthService(repo ports.AuthRepository, jwtAdapter *auth.JWTAdapter) *AuthService {
+func NewAuthService(repo ports.AuthRepository, jwtAdapter *auth.JWTAdapter, refreshTTL time.Duration, activity *ActivityLog) *AuthService {
 	return &AuthService{
 		repo:       repo,
 		jwtAdapter: jwtAdapter,
+		refreshTTL: refreshTTL,
+		activity:   activity,
 	}
 }
 
-func (s *AuthService) Register(email, password, orgName string) (string, error) {
+func (s *AuthService) Register(ctx context.Context, email, password, orgName string, client domain.ClientInfo) (*domain.TokenPair, error) {
 	// Verificar si el usuario ya existe
-	if _, err := s.repo.FindUserByEmail(email); err == nil {
-		return "", errors.New("el usuario ya existe")
+	if _, err := s.repo.FindUserByEmail(ctx, email); err == nil {
+		return nil, errors.New("el usuario ya existe")
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), 12)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	newOrg := &domain.Organization{Name: orgName}
@@ -41,40 +48,182 @@ func (s *AuthService) Register(email, password, orgName string) (string, error)
 	userOrg := &domain.UserOrganization{
 		RoleDefault: domain.RoleConsultora,
 		Status:      domain.MemberActivo,
+		JoinedAt:    time.Now(),
 	}
 
 	// Transacción en repositorio
-	if err := s.repo.CreateUserWithOrg(newUser, newOrg, userOrg); err != nil {
-		return "", err
+	if err := s.repo.CreateUserWithOrg(ctx, newUser, newOrg, userOrg); err != nil {
+		return nil, err
 	}
+	s.activity.record(ctx, newOrg.ID, newUser.ID, domain.EntityOrganization, newOrg.ID, domain.ActivityCreate, nil, newOrg)
+	s.activity.record(ctx, newOrg.ID, newUser.ID, domain.EntityUser, newUser.ID, domain.ActivityCreate, nil, newUser)
 
-	// Generar Token con contexto (OrgID creada, Rol Default)
-	return s.jwtAdapter.GenerateToken(newUser.ID, newOrg.ID, string(domain.RoleConsultora))
+	// Abrir sesión con contexto (OrgID creada, Rol Default)
+	return s.openSession(ctx, newUser.ID, newOrg.ID, domain.RoleConsultora, client)
 }
 
-func (s *AuthService) Login(email, password string) (string, error) {
-	user, err := s.repo.FindUserByEmail(email)
+func (s *AuthService) Login(ctx context.Context, email, password, orgID string, client domain.ClientInfo) (*domain.TokenPair, error) {
+	user, err := s.repo.FindUserByEmail(ctx, email)
 	if err != nil {
-		return "", errors.New("credenciales inválidas")
+		return nil, errors.New("credenciales inválidas")
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", errors.New("credenciales inválidas")
+		return nil, errors.New("credenciales inválidas")
+	}
+
+	// Si el cliente eligió organización, abrir la sesión directamente en ella
+	if orgID != "" {
+		member, err := s.repo.FindMembership(ctx, user.ID, orgID)
+		if err != nil || member.Status != domain.MemberActivo {
+			return nil, domain.ErrForbidden
+		}
+		return s.openSession(ctx, user.ID, member.OrganizationID, member.RoleDefault, client)
 	}
 
 	// Obtener la organización principal del usuario
-	userOrg, err := s.repo.GetUserPrimaryOrg(user.ID)
+	userOrg, err := s.repo.GetUserPrimaryOrg(ctx, user.ID)
 	if err != nil {
 		// En un caso real podríamos devolver un token "sin org" o error.
 		// Asumimos error para forzar al usuario a tener organización.
-		return "", errors.New("error recuperando datos de organización del usuario")
+		return nil, errors.New("error recuperando datos de organización del usuario")
+	}
+
+	return s.openSession(ctx, user.ID, userOrg.OrganizationID, userOrg.RoleDefault, client)
+}
+
+// Logout revoca la sesión y bloquea sus access tokens hasta que venzan
+func (s *AuthService) Logout(ctx context.Context, sessionID string) error {
+	if err := s.repo.RevokeSession(ctx, sessionID); err != nil {
+		return err
+	}
+	if err := s.repo.RevokeToken(ctx, sessionID, time.Now().Add(s.jwtAdapter.TTL())); err != nil {
+		return err
+	}
+	if session, err := s.repo.FindSession(ctx, sessionID); err == nil {
+		s.activity.record(ctx, session.OrganizationID, "", domain.EntitySession, session.ID, domain.ActivityLogout, nil, nil)
+	}
+	return nil
+}
+
+func (s *AuthService) ListOrganizations(ctx context.Context, userID string) ([]domain.OrganizationMembership, error) {
+	return s.repo.ListUserOrganizations(ctx, userID)
+}
+
+// SwitchOrganization abre una sesión nueva en otra organización en la que el usuario esté Activo
+// y cierra la sesión desde la que se pidió el cambio
+func (s *AuthService) SwitchOrganization(ctx context.Context, userID, orgID, sessionID string, client domain.ClientInfo) (*domain.TokenPair, error) {
+	member, err := s.repo.FindMembership(ctx, userID, orgID)
+	if err != nil || member.Status != domain.MemberActivo {
+		return nil, domain.ErrForbidden
+	}
+
+	pair, err := s.openSession(ctx, userID, member.OrganizationID, member.RoleDefault, client)
+	if err != nil {
+		return nil, err
+	}
+	if sessionID != "" {
+		if err := s.Logout(ctx, sessionID); err != nil {
+			return nil, err
+		}
+	}
+	return pair, nil
+}
+
+// --- Sessions ---
+
+// Refresh rota el refresh token. Presentar un token ya rotado se considera robo:
+// se revoca la sesión completa (detección de reutilización).
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string, client domain.ClientInfo) (*domain.TokenPair, error) {
+	sessionID, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok {
+		return nil, domain.ErrForbidden
+	}
+
+	session, err := s.repo.FindSession(ctx, sessionID)
+	if err != nil || !session.IsActive(time.Now()) {
+		return nil, domain.ErrForbidden
 	}
 
-	return s.jwtAdapter.GenerateToken(user.ID, userOrg.OrganizationID, string(userOrg.RoleDefault))
+	presented := hashToken(s.jwtAdapter.Secret, secret)
+	if presented != session.RefreshTokenHash {
+		_ = s.Logout(ctx, session.ID)
+		return nil, errors.New("refresh token reutilizado: sesión revocada")
+	}
+
+	// La membresía puede haber cambiado desde el login
+	member, err := s.repo.FindMembership(ctx, session.UserID, session.OrganizationID)
+	if err != nil || member.Status != domain.MemberActivo {
+		_ = s.Logout(ctx, session.ID)
+		return nil, domain.ErrForbidden
+	}
+
+	newSecret, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.RotateSession(ctx, session.ID, presented, hashToken(s.jwtAdapter.Secret, newSecret), time.Now().Add(s.refreshTTL)); err != nil {
+		// Otra petición rotó el mismo token en paralelo: también es reutilización
+		_ = s.Logout(ctx, session.ID)
+		return nil, errors.New("refresh token reutilizado: sesión revocada")
+	}
+
+	access, err := s.jwtAdapter.GenerateToken(session.UserID, session.OrganizationID, string(member.RoleDefault), session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TokenPair{
+		AccessToken:  access,
+		RefreshToken: session.ID + "." + newSecret,
+		ExpiresIn:    int(s.jwtAdapter.TTL().Seconds()),
+		SessionID:    session.ID,
+	}, nil
+}
+
+func (s *AuthService) ListSessions(ctx context.Context, userID string) ([]domain.Session, error) {
+	return s.repo.ListActiveSessions(ctx, userID)
 }
 
-func (s *AuthService) Logout(token string) error {
-	// 24 hours expiration default
-	expiration := time.Now().Add(24 * time.Hour).Unix()
-	return s.repo.RevokeToken(token, expiration)
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	session, err := s.repo.FindSession(ctx, sessionID)
+	if err != nil || session.UserID != userID {
+		return domain.ErrNotFound
+	}
+	return s.Logout(ctx, sessionID)
+}
+
+// openSession crea la sesión (jti) y emite el par access/refresh
+func (s *AuthService) openSession(ctx context.Context, userID, orgID string, role domain.Role, client domain.ClientInfo) (*domain.TokenPair, error) {
+	secret, err := newOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := &domain.Session{
+		ID:               uuid.New().String(),
+		UserID:           userID,
+		OrganizationID:   orgID,
+		Role:             role,
+		RefreshTokenHash: hashToken(s.jwtAdapter.Secret, secret),
+		UserAgent:        client.UserAgent,
+		IP:               client.IP,
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(s.refreshTTL),
+	}
+	if err := s.repo.CreateSession(ctx, session); err != nil {
+		return nil, err
+	}
+	s.activity.record(ctx, orgID, userID, domain.EntitySession, session.ID, domain.ActivityLogin, nil, session)
+
+	access, err := s.jwtAdapter.GenerateToken(userID, orgID, string(role), session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TokenPair{
+		AccessToken:  access,
+		RefreshToken: session.ID + "." + secret,
+		ExpiresIn:    int(s.jwtAdapter.TTL().Seconds()),
+		SessionID:    session.ID,
+	}, nil
 }
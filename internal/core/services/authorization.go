@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// authorizeAudit aplica la política por auditoría (AuditAssignment.RoleInAudit).
+// Las acciones de escritura exigen además asignación aceptada y auditoría no finalizada.
+func authorizeAudit(ctx context.Context, repo ports.AuditRepository, auditID, userID string, action domain.Action) (*domain.AuditAssignment, error) {
+	assignment, err := repo.FindAuditAssignment(ctx, auditID, userID)
+	if err != nil || !assignment.IsActive {
+		return nil, domain.ErrNotAssigned
+	}
+	if !domain.AuditRoleAllows(assignment.RoleInAudit, action) {
+		return nil, fmt.Errorf("%w: %s requires a different role in this audit", domain.ErrForbidden, action)
+	}
+	if action.IsReadOnly() {
+		return assignment, nil
+	}
+
+	if assignment.AcceptanceStatus != domain.AcceptAceptado {
+		return nil, fmt.Errorf("%w: assignment has not been accepted", domain.ErrForbidden)
+	}
+	audit, err := repo.GetAuditByID(ctx, auditID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	if audit.Status == domain.AuditFinalizada {
+		return nil, domain.ErrAuditClosed
+	}
+	return assignment, nil
+}
+
+// authorizeOrg aplica la política de organización sobre la membresía vigente
+// (no sobre el rol del token, que puede estar desactualizado)
+func authorizeOrg(ctx context.Context, repo ports.OrganizationRepository, userID, orgID string, action domain.Action) error {
+	member, err := repo.FindUserOrg(ctx, userID, orgID)
+	if err != nil || member.Status != domain.MemberActivo {
+		return fmt.Errorf("%w: not an active member of the organization", domain.ErrForbidden)
+	}
+	if !domain.OrgRoleAllows(member.RoleDefault, action) {
+		return fmt.Errorf("%w: %s requires a different role in the organization", domain.ErrForbidden, action)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/repository"
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+func TestAuthorizeAudit(t *testing.T) {
+	cases := []struct {
+		name       string
+		role       domain.Role // vacío = sin asignación
+		acceptance domain.AcceptanceStatus
+		inactive   bool
+		status     domain.AuditStatus
+		action     domain.Action
+		want       error
+	}{
+		{"not assigned", "", "", false, domain.AuditEnCurso, domain.ActionAuditRead, domain.ErrNotAssigned},
+		{"inactive assignment", domain.RoleAuditorLider, domain.AcceptAceptado, true, domain.AuditEnCurso, domain.ActionAuditRead, domain.ErrNotAssigned},
+		{"pending may read", domain.RoleAuxiliar, domain.AcceptPendiente, false, domain.AuditEnCurso, domain.ActionAuditRead, nil},
+		{"pending write", domain.RoleAuxiliar, domain.AcceptPendiente, false, domain.AuditEnCurso, domain.ActionFindingWrite, domain.ErrForbidden},
+		{"rejected write", domain.RoleAuditorInterno, domain.AcceptRechazado, false, domain.AuditEnCurso, domain.ActionChecklistAnswer, domain.ErrForbidden},
+		{"observer write", domain.RoleObservador, domain.AcceptAceptado, false, domain.AuditEnCurso, domain.ActionFindingWrite, domain.ErrForbidden},
+		{"observer read", domain.RoleObservador, domain.AcceptAceptado, false, domain.AuditEnCurso, domain.ActionReportRead, nil},
+		{"auxiliar finalize", domain.RoleAuxiliar, domain.AcceptAceptado, false, domain.AuditEnCurso, domain.ActionAuditFinalize, domain.ErrForbidden},
+		{"accepted write", domain.RoleAuxiliar, domain.AcceptAceptado, false, domain.AuditEnCurso, domain.ActionEvidenceUpload, nil},
+		{"closed audit write", domain.RoleAuditorLider, domain.AcceptAceptado, false, domain.AuditFinalizada, domain.ActionFindingWrite, domain.ErrAuditClosed},
+		{"closed audit read", domain.RoleAuditorLider, domain.AcceptAceptado, false, domain.AuditFinalizada, domain.ActionReportRead, nil},
+	}
+
+	ctx := context.Background()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := repository.NewMemoryRepository()
+			audit := &domain.Audit{Title: "Auditoría", OrgOwnerID: "org-1", Status: c.status}
+			if err := repo.CreateAudit(ctx, audit); err != nil {
+				t.Fatal(err)
+			}
+			if c.role != "" {
+				assignment := &domain.AuditAssignment{AuditID: audit.ID, UserID: "user-1", RoleInAudit: c.role, AcceptanceStatus: c.acceptance}
+				if err := repo.AssignUserToAudit(ctx, assignment); err != nil {
+					t.Fatal(err)
+				}
+				if c.inactive {
+					assignment.IsActive = false
+					if err := repo.UpdateAuditAssignment(ctx, assignment); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			_, err := authorizeAudit(ctx, repo, audit.ID, "user-1", c.action)
+			if c.want == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.want != nil && !errors.Is(err, c.want) {
+				t.Fatalf("got %v, want %v", err, c.want)
+			}
+		})
+	}
+}
+
+func TestAuthorizeOrg(t *testing.T) {
+	cases := []struct {
+		name   string
+		role   domain.Role // vacío = no es miembro
+		status domain.MemberStatus
+		action domain.Action
+		want   error
+	}{
+		{"not a member", "", "", domain.ActionStaffList, domain.ErrForbidden},
+		{"consultora invites", domain.RoleConsultora, domain.MemberActivo, domain.ActionStaffInvite, nil},
+		{"inactive consultora", domain.RoleConsultora, domain.MemberInactivo, domain.ActionStaffInvite, domain.ErrForbidden},
+		{"invited consultora", domain.RoleConsultora, domain.MemberInvitado, domain.ActionStaffList, domain.ErrForbidden},
+		{"lead cannot invite", domain.RoleAuditorLider, domain.MemberActivo, domain.ActionStaffInvite, domain.ErrForbidden},
+		{"auxiliar lists staff", domain.RoleAuxiliar, domain.MemberActivo, domain.ActionStaffList, domain.ErrForbidden},
+		{"client reads own audits", domain.RoleCliente, domain.MemberActivo, domain.ActionAuditeeRead, nil},
+	}
+
+	ctx := context.Background()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := repository.NewMemoryRepository()
+			owner := &domain.User{Email: "owner@example.com", Password: "x"}
+			org := &domain.Organization{Name: "Consultora"}
+			if err := repo.CreateUserWithOrg(ctx, owner, org, &domain.UserOrganization{RoleDefault: domain.RoleConsultora, JoinedAt: time.Now()}); err != nil {
+				t.Fatal(err)
+			}
+			userID := "someone-else"
+			if c.role != "" {
+				user := &domain.User{Email: "member@example.com", Password: "x"}
+				member := &domain.UserOrganization{OrganizationID: org.ID, RoleDefault: c.role, Status: c.status, JoinedAt: time.Now()}
+				if err := repo.CreateUserAndAddToOrg(ctx, user, member); err != nil {
+					t.Fatal(err)
+				}
+				userID = user.ID
+			}
+
+			err := authorizeOrg(ctx, repo, userID, org.ID, c.action)
+			if c.want == nil && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.want != nil && !errors.Is(err, c.want) {
+				t.Fatalf("got %v, want %v", err, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// sequenceEpoch: SEQUENCE de iCalendar es un entero de 32 bits, así que la versión
+// de cada evento se cuenta en segundos desde esta fecha y no desde 1970
+var sequenceEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// CalendarService arma el feed iCalendar personal de cada auditor a partir de sus
+// asignaciones aceptadas y de las sesiones del plan que conduce
+type CalendarService struct {
+	repo         ports.CalendarRepository
+	auditRepo    ports.AuditRepository
+	planningRepo ports.PlanningRepository
+	tokenSecret  string
+}
+
+func NewCalendarService(repo ports.CalendarRepository, auditRepo ports.AuditRepository, planningRepo ports.PlanningRepository, tokenSecret string) *CalendarService {
+	return &CalendarService{
+		repo:         repo,
+		auditRepo:    auditRepo,
+		planningRepo: planningRepo,
+		tokenSecret:  tokenSecret,
+	}
+}
+
+// EnableFeed genera (o regenera) el token del feed; la URL anterior deja de funcionar
+func (s *CalendarService) EnableFeed(ctx context.Context, userID string) (string, error) {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	feed := &domain.CalendarFeed{
+		UserID:    userID,
+		TokenHash: hashToken(s.tokenSecret, token),
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.SaveCalendarFeed(ctx, feed); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (s *CalendarService) DisableFeed(ctx context.Context, userID string) error {
+	return s.repo.DeleteCalendarFeed(ctx, userID)
+}
+
+// Feed devuelve un evento por auditoría con ventana planificada y uno por sesión.
+// Las auditorías pausadas se publican como canceladas para que desaparezcan de la agenda.
+func (s *CalendarService) Feed(ctx context.Context, token string) ([]domain.CalendarEvent, error) {
+	feed, err := s.repo.FindCalendarFeedByHash(ctx, hashToken(s.tokenSecret, token))
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	audits, err := s.auditRepo.GetAuditsByUserID(ctx, feed.UserID, domain.AcceptAceptado)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.planningRepo.ListAuditorSessions(ctx, feed.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]domain.Audit, len(audits))
+	events := []domain.CalendarEvent{}
+	for _, audit := range audits {
+		byID[audit.ID] = audit
+		if audit.PlannedStart == nil {
+			continue
+		}
+		events = append(events, domain.CalendarEvent{
+			UID:          "audit-" + audit.ID + "@iso-stack",
+			Summary:      "Auditoría: " + audit.Title,
+			Description:  fmt.Sprintf("Estado: %s", audit.Status),
+			Start:        *audit.PlannedStart,
+			End:          *audit.PlannedEnd,
+			Status:       eventStatus(audit),
+			Sequence:     eventSequence(audit.UpdatedAt),
+			LastModified: audit.UpdatedAt,
+		})
+	}
+
+	for _, session := range sessions {
+		// Solo sesiones de auditorías aceptadas: si rechazó o lo reasignaron, la sesión sale del feed
+		audit, ok := byID[session.AuditID]
+		if !ok {
+			continue
+		}
+		modified := session.UpdatedAt
+		if audit.UpdatedAt.After(modified) {
+			modified = audit.UpdatedAt
+		}
+
+		description := "Auditoría: " + audit.Title
+		if session.Auditee != "" {
+			description += "\nEntrevistado: " + session.Auditee
+		}
+		if session.Notes != "" {
+			description += "\n" + session.Notes
+		}
+		events = append(events, domain.CalendarEvent{
+			UID:          "session-" + session.ID + "@iso-stack",
+			Summary:      session.Process + " - " + audit.Title,
+			Description:  description,
+			Location:     session.Site,
+			Start:        session.StartsAt,
+			End:          session.EndsAt,
+			Status:       eventStatus(audit),
+			Sequence:     eventSequence(modified),
+			LastModified: modified,
+		})
+	}
+
+	// El último acceso es informativo: un error acá no debe cortar la sincronización
+	_ = s.repo.TouchCalendarFeed(ctx, feed.UserID, time.Now())
+	return events, nil
+}
+
+func eventStatus(audit domain.Audit) domain.CalendarEventStatus {
+	if audit.Status == domain.AuditPausada {
+		return domain.EventCancelled
+	}
+	return domain.EventConfirmed
+}
+
+func eventSequence(modified time.Time) int {
+	if modified.Before(sequenceEpoch) {
+		return 0
+	}
+	return int(modified.Sub(sequenceEpoch) / time.Second)
+}
@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// capaActor identifica qué parte de la auditoría puede ejecutar una transición
+type capaActor int
+
+const (
+	actorResponsible capaActor = iota // Organización auditada (responsable de la CAPA)
+	actorLead                         // Quien puede revisar CAPAs en la auditoría (capa:review)
+)
+
+// capaTransitions: estado actual -> estado destino -> quién puede moverla
+var capaTransitions = map[domain.CAPAStatus]map[domain.CAPAStatus]capaActor{
+	domain.CAPAAbierta: {
+		domain.CAPAPropuesta: actorResponsible,
+	},
+	domain.CAPAPropuesta: {
+		domain.CAPAAprobada: actorLead,
+		domain.CAPAAbierta:  actorLead, // Devuelta para rehacer el análisis
+	},
+	domain.CAPAAprobada: {
+		domain.CAPAImplementada: actorResponsible,
+	},
+	domain.CAPAImplementada: {
+		domain.CAPAVerificada: actorLead,
+		domain.CAPARechazada:  actorLead,
+	},
+}
+
+type CAPAService struct {
+	repo        ports.CAPARepository
+	findingRepo ports.FindingRepository
+	auditRepo   ports.AuditRepository
+	orgRepo     ports.OrganizationRepository
+	activity    *ActivityLog
+}
+
+func NewCAPAService(repo ports.CAPARepository, findingRepo ports.FindingRepository, auditRepo ports.AuditRepository, orgRepo ports.OrganizationRepository, activity *ActivityLog) *CAPAService {
+	return &CAPAService{
+		repo:        repo,
+		findingRepo: findingRepo,
+		auditRepo:   auditRepo,
+		orgRepo:     orgRepo,
+		activity:    activity,
+	}
+}
+
+func (s *CAPAService) CreateCAPA(ctx context.Context, auditID, findingID, userID, responsibleUserID, responsibleOrgID string, dueDate time.Time) (*domain.CorrectiveAction, error) {
+	if err := s.authorizeAuditTeam(ctx, auditID, userID, domain.ActionCAPACreate); err != nil {
+		return nil, err
+	}
+
+	finding, err := s.findingRepo.FindFinding(ctx, auditID, findingID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	if finding.Severity != domain.SeverityMayor && finding.Severity != domain.SeverityMenor {
+		return nil, fmt.Errorf("%w: corrective actions apply only to Mayor or Menor nonconformities", domain.ErrInvalidInput)
+	}
+
+	if dueDate.IsZero() || dueDate.Before(time.Now()) {
+		return nil, fmt.Errorf("%w: due_date must be in the future", domain.ErrInvalidInput)
+	}
+
+	// La CAPA queda a cargo de la organización auditada (la propia, en una auditoría interna)
+	audit, err := s.auditRepo.GetAuditByID(ctx, auditID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	auditee := audit.AuditeeOrgID
+	if auditee == "" {
+		auditee = audit.OrgOwnerID
+	}
+	if responsibleOrgID == "" {
+		responsibleOrgID = auditee
+	}
+	if responsibleOrgID != auditee {
+		return nil, fmt.Errorf("%w: responsible organization must be the audited organization", domain.ErrInvalidInput)
+	}
+
+	member, err := s.orgRepo.FindUserOrg(ctx, responsibleUserID, responsibleOrgID)
+	if err != nil || member.Status != domain.MemberActivo {
+		return nil, fmt.Errorf("%w: responsible user must be an active member of the organization", domain.ErrInvalidInput)
+	}
+
+	capa := &domain.CorrectiveAction{
+		FindingID:         findingID,
+		AuditID:           auditID,
+		ResponsibleUserID: responsibleUserID,
+		ResponsibleOrgID:  responsibleOrgID,
+		DueDate:           dueDate,
+		Status:            domain.CAPAAbierta,
+		CreatedBy:         userID,
+	}
+	if err := s.repo.CreateCAPA(ctx, capa); err != nil {
+		return nil, err
+	}
+	s.record(ctx, capa, userID, domain.ActivityCreate, nil)
+	return capa, nil
+}
+
+func (s *CAPAService) ListAuditCAPAs(ctx context.Context, auditID, userID string) ([]domain.CorrectiveAction, error) {
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionAuditRead); err != nil {
+		return nil, err
+	}
+	return s.repo.ListCAPAsByAudit(ctx, auditID)
+}
+
+// ListOrgCAPAs devuelve las CAPAs que la organización auditada debe atender
+func (s *CAPAService) ListOrgCAPAs(ctx context.Context, orgID, userID string) ([]domain.CorrectiveAction, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionCAPARead); err != nil {
+		return nil, err
+	}
+	return s.repo.ListCAPAsByOrg(ctx, orgID)
+}
+
+func (s *CAPAService) GetCAPA(ctx context.Context, capaID, userID, orgID string) (*domain.CorrectiveAction, error) {
+	capa, err := s.repo.FindCAPA(ctx, capaID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	if !s.isResponsible(ctx, capa, userID, orgID) && !s.isAuditTeam(ctx, capa, userID) {
+		return nil, domain.ErrForbidden
+	}
+	return capa, nil
+}
+
+func (s *CAPAService) ProposeCAPA(ctx context.Context, capaID, userID, orgID, rootCause, actionPlan string) (*domain.CorrectiveAction, error) {
+	if rootCause == "" || actionPlan == "" {
+		return nil, fmt.Errorf("%w: root_cause and action_plan are required", domain.ErrInvalidInput)
+	}
+
+	capa, err := s.repo.FindCAPA(ctx, capaID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	capa.RootCause = rootCause
+	capa.ActionPlan = actionPlan
+	return s.apply(ctx, capa, userID, orgID, domain.CAPAPropuesta, "")
+}
+
+func (s *CAPAService) TransitionCAPA(ctx context.Context, capaID, userID, orgID string, target domain.CAPAStatus, notes string) (*domain.CorrectiveAction, error) {
+	if target == domain.CAPAPropuesta {
+		return nil, fmt.Errorf("%w: use the propose endpoint to submit root cause and action plan", domain.ErrInvalidInput)
+	}
+
+	capa, err := s.repo.FindCAPA(ctx, capaID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	return s.apply(ctx, capa, userID, orgID, target, notes)
+}
+
+// apply valida la transición contra la tabla y el actor, y persiste el nuevo estado
+func (s *CAPAService) apply(ctx context.Context, capa *domain.CorrectiveAction, userID, orgID string, target domain.CAPAStatus, notes string) (*domain.CorrectiveAction, error) {
+	actor, ok := capaTransitions[capa.Status][target]
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot move corrective action from %s to %s", domain.ErrInvalidInput, capa.Status, target)
+	}
+
+	switch actor {
+	case actorResponsible:
+		if !s.isResponsible(ctx, capa, userID, orgID) {
+			return nil, domain.ErrForbidden
+		}
+	case actorLead:
+		if !s.isAuditLead(ctx, capa, userID) {
+			return nil, domain.ErrForbidden
+		}
+	}
+
+	before := *capa
+	now := time.Now()
+	capa.Status = target
+	if notes != "" {
+		capa.ReviewNotes = notes
+	}
+	switch target {
+	case domain.CAPAImplementada:
+		capa.ImplementedAt = &now
+	case domain.CAPAVerificada, domain.CAPARechazada:
+		capa.VerifiedBy = userID
+		capa.VerifiedAt = &now
+	}
+
+	if err := s.repo.UpdateCAPA(ctx, capa); err != nil {
+		return nil, err
+	}
+	s.record(ctx, capa, userID, domain.ActivityTransition, &before)
+	return capa, nil
+}
+
+// record anota el cambio en la bitácora de la organización dueña de la auditoría y,
+// si es otra, en la de la organización responsable
+func (s *CAPAService) record(ctx context.Context, capa *domain.CorrectiveAction, userID string, action domain.ActivityAction, before *domain.CorrectiveAction) {
+	var previous interface{}
+	if before != nil {
+		previous = before
+	}
+	audit, err := s.auditRepo.GetAuditByID(ctx, capa.AuditID)
+	if err != nil {
+		return
+	}
+	s.activity.record(ctx, audit.OrgOwnerID, userID, domain.EntityCAPA, capa.ID, action, previous, capa)
+	if capa.ResponsibleOrgID != audit.OrgOwnerID {
+		s.activity.record(ctx, capa.ResponsibleOrgID, userID, domain.EntityCAPA, capa.ID, action, previous, capa)
+	}
+}
+
+// isResponsible: el usuario responsable o cualquier miembro activo de su organización
+func (s *CAPAService) isResponsible(ctx context.Context, capa *domain.CorrectiveAction, userID, orgID string) bool {
+	if capa.ResponsibleUserID == userID {
+		return true
+	}
+	if orgID != capa.ResponsibleOrgID {
+		return false
+	}
+	member, err := s.orgRepo.FindUserOrg(ctx, userID, orgID)
+	return err == nil && member.Status == domain.MemberActivo
+}
+
+func (s *CAPAService) isAuditTeam(ctx context.Context, capa *domain.CorrectiveAction, userID string) bool {
+	_, err := authorizeAudit(ctx, s.auditRepo, capa.AuditID, userID, domain.ActionAuditRead)
+	return err == nil
+}
+
+// isAuditLead: quien puede revisar las CAPAs de la auditoría (capa:review)
+func (s *CAPAService) isAuditLead(ctx context.Context, capa *domain.CorrectiveAction, userID string) bool {
+	return s.authorizeAuditTeam(ctx, capa.AuditID, userID, domain.ActionCAPAReview) == nil
+}
+
+// authorizeAuditTeam es authorizeAudit sin exigir que la auditoría siga abierta: las CAPAs
+// se abren con el informe emitido y se verifican habitualmente después de finalizarla
+func (s *CAPAService) authorizeAuditTeam(ctx context.Context, auditID, userID string, action domain.Action) error {
+	assignment, err := s.auditRepo.FindAuditAssignment(ctx, auditID, userID)
+	if err != nil || !assignment.IsActive {
+		return domain.ErrNotAssigned
+	}
+	if !domain.AuditRoleAllows(assignment.RoleInAudit, action) {
+		return fmt.Errorf("%w: %s requires a different role in this audit", domain.ErrForbidden, action)
+	}
+	if assignment.AcceptanceStatus != domain.AcceptAceptado {
+		return fmt.Errorf("%w: assignment has not been accepted", domain.ErrForbidden)
+	}
+	return nil
+}
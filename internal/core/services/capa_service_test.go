@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+// Las CAPAs se abren con el informe emitido: una auditoría Finalizada no las bloquea
+func TestCreateCAPAOnFinalizedAudit(t *testing.T) {
+	ctx := context.Background()
+	repo := newSQLiteRepo(t)
+	capas := NewCAPAService(repo, repo, repo, repo, NewActivityLog(repo))
+
+	lead := &domain.User{Email: "lead@example.com", Password: "x"}
+	org := &domain.Organization{Name: "Consultora"}
+	if err := repo.CreateUserWithOrg(ctx, lead, org, &domain.UserOrganization{RoleDefault: domain.RoleConsultora, Status: domain.MemberActivo, JoinedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	observer := &domain.User{Email: "observer@example.com", Password: "x"}
+	if err := repo.CreateUserAndAddToOrg(ctx, observer, &domain.UserOrganization{OrganizationID: org.ID, RoleDefault: domain.RoleObservador, Status: domain.MemberActivo, JoinedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	audit := &domain.Audit{Title: "Auditoría interna", OrgOwnerID: org.ID}
+	if err := repo.CreateAudit(ctx, audit); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range []*domain.AuditAssignment{
+		{AuditID: audit.ID, UserID: lead.ID, RoleInAudit: domain.RoleAuditorLider, AcceptanceStatus: domain.AcceptAceptado},
+		{AuditID: audit.ID, UserID: observer.ID, RoleInAudit: domain.RoleObservador, AcceptanceStatus: domain.AcceptAceptado},
+	} {
+		if err := repo.AssignUserToAudit(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	finding := &domain.Finding{AuditID: audit.ID, Severity: domain.SeverityMayor, Description: "Sin control de documentos", ReportedBy: lead.ID}
+	if err := repo.CreateFinding(ctx, finding); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UpdateAuditStatus(ctx, &domain.AuditStatusChange{AuditID: audit.ID, FromStatus: domain.AuditPlanificada, ToStatus: domain.AuditFinalizada, ChangedBy: lead.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if closed, err := repo.GetAuditByID(ctx, audit.ID); err != nil || closed.Status != domain.AuditFinalizada {
+		t.Fatalf("audit not finalized: %+v, %v", closed, err)
+	}
+
+	due := time.Now().Add(30 * 24 * time.Hour)
+	capa, err := capas.CreateCAPA(ctx, audit.ID, finding.ID, lead.ID, lead.ID, "", due)
+	if err != nil {
+		t.Fatalf("CreateCAPA on a finalized audit: %v", err)
+	}
+	if capa.ResponsibleOrgID != org.ID || capa.Status != domain.CAPAAbierta {
+		t.Fatalf("unexpected CAPA: %+v", capa)
+	}
+
+	// El rol en la auditoría se sigue exigiendo
+	if _, err := capas.CreateCAPA(ctx, audit.ID, finding.ID, observer.ID, lead.ID, "", due); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("observer: got %v, want ErrForbidden", err)
+	}
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// customStandard es la "norma" con la que figuran las preguntas propias de la consultora
+const customStandard = "Personalizada"
+
+// ChecklistTemplateService gestiona las plantillas de checklist de cada organización.
+// Las versiones publicadas no se modifican: editar crea la versión siguiente.
+type ChecklistTemplateService struct {
+	repo         ports.ChecklistTemplateRepository
+	standardRepo ports.StandardRepository
+	orgRepo      ports.OrganizationRepository
+}
+
+func NewChecklistTemplateService(repo ports.ChecklistTemplateRepository, standardRepo ports.StandardRepository, orgRepo ports.OrganizationRepository) *ChecklistTemplateService {
+	return &ChecklistTemplateService{
+		repo:         repo,
+		standardRepo: standardRepo,
+		orgRepo:      orgRepo,
+	}
+}
+
+func (s *ChecklistTemplateService) ListTemplates(ctx context.Context, orgID string) ([]domain.ChecklistTemplate, error) {
+	return s.repo.ListChecklistTemplates(ctx, orgID)
+}
+
+func (s *ChecklistTemplateService) GetTemplate(ctx context.Context, orgID, templateID string) (*domain.ChecklistTemplateDetail, error) {
+	tpl, err := s.repo.FindChecklistTemplate(ctx, orgID, templateID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	items, err := s.repo.ListChecklistTemplateItems(ctx, tpl.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.ChecklistTemplateDetail{ChecklistTemplate: *tpl, Items: items}, nil
+}
+
+func (s *ChecklistTemplateService) ListVersions(ctx context.Context, orgID, templateID string) ([]domain.ChecklistTemplate, error) {
+	tpl, err := s.repo.FindChecklistTemplate(ctx, orgID, templateID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	return s.repo.ListChecklistTemplateVersions(ctx, orgID, tpl.FamilyID)
+}
+
+func (s *ChecklistTemplateService) CreateTemplate(ctx context.Context, orgID, userID string, input *domain.ChecklistTemplateDetail) (*domain.ChecklistTemplateDetail, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionTemplateManage); err != nil {
+		return nil, err
+	}
+	tpl := &domain.ChecklistTemplate{
+		OrganizationID: orgID,
+		Version:        1,
+		Name:           input.Name,
+		Description:    input.Description,
+		CreatedBy:      userID,
+	}
+	return s.publish(ctx, tpl, input.Items)
+}
+
+func (s *ChecklistTemplateService) UpdateTemplate(ctx context.Context, orgID, templateID, userID string, input *domain.ChecklistTemplateDetail) (*domain.ChecklistTemplateDetail, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionTemplateManage); err != nil {
+		return nil, err
+	}
+	current, err := s.repo.FindChecklistTemplate(ctx, orgID, templateID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	versions, err := s.repo.ListChecklistTemplateVersions(ctx, orgID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) > 0 && versions[0].ID != current.ID {
+		return nil, fmt.Errorf("%w: version %d is not the latest (%d)", domain.ErrConflict, current.Version, versions[0].Version)
+	}
+
+	tpl := &domain.ChecklistTemplate{
+		OrganizationID: orgID,
+		FamilyID:       current.FamilyID,
+		Version:        current.Version + 1,
+		Name:           input.Name,
+		Description:    input.Description,
+		CreatedBy:      userID,
+	}
+	return s.publish(ctx, tpl, input.Items)
+}
+
+// CloneTemplate copia una versión como versión 1 de una plantilla nueva
+func (s *ChecklistTemplateService) CloneTemplate(ctx context.Context, orgID, templateID, userID, name string) (*domain.ChecklistTemplateDetail, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionTemplateManage); err != nil {
+		return nil, err
+	}
+	source, err := s.GetTemplate(ctx, orgID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = source.Name + " (copia)"
+	}
+
+	tpl := &domain.ChecklistTemplate{
+		OrganizationID: orgID,
+		Version:        1,
+		Name:           name,
+		Description:    source.Description,
+		CreatedBy:      userID,
+	}
+	return s.publish(ctx, tpl, source.Items)
+}
+
+func (s *ChecklistTemplateService) publish(ctx context.Context, tpl *domain.ChecklistTemplate, input []domain.ChecklistTemplateItem) (*domain.ChecklistTemplateDetail, error) {
+	tpl.Name = strings.TrimSpace(tpl.Name)
+	if tpl.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+	items, err := s.resolveItems(ctx, tpl.OrganizationID, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateChecklistTemplate(ctx, tpl, items); err != nil {
+		return nil, err
+	}
+	return &domain.ChecklistTemplateDetail{ChecklistTemplate: *tpl, Items: items}, nil
+}
+
+// resolveItems completa las preguntas que referencian el catálogo (el texto puede
+// personalizarse) y numera las preguntas propias que no traen número
+func (s *ChecklistTemplateService) resolveItems(ctx context.Context, orgID string, input []domain.ChecklistTemplateItem) ([]domain.ChecklistTemplateItem, error) {
+	if len(input) == 0 {
+		return nil, fmt.Errorf("%w: a template needs at least one item", domain.ErrInvalidInput)
+	}
+
+	items := make([]domain.ChecklistTemplateItem, 0, len(input))
+	for i, in := range input {
+		item := domain.ChecklistTemplateItem{
+			Position:         i,
+			Standard:         in.Standard,
+			ClauseNumber:     in.ClauseNumber,
+			Requirement:      in.Requirement,
+			ExpectedEvidence: in.ExpectedEvidence,
+		}
+
+		if in.ClauseID != "" {
+			clause, err := s.standardRepo.FindClause(ctx, in.ClauseID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: clause %s does not exist", domain.ErrInvalidInput, in.ClauseID)
+			}
+			// La norma tiene que ser visible para la organización (global o propia)
+			standard, err := s.standardRepo.FindStandard(ctx, orgID, clause.StandardID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: clause %s does not exist", domain.ErrInvalidInput, in.ClauseID)
+			}
+			item.ClauseID = clause.ID
+			item.Standard = standard.Label()
+			item.ClauseNumber = clause.Number
+			if item.Requirement == "" {
+				item.Requirement = clause.Requirement
+			}
+			if item.ExpectedEvidence == "" {
+				item.ExpectedEvidence = clause.ExpectedEvidence
+			}
+		} else {
+			if item.Standard == "" {
+				item.Standard = customStandard
+			}
+			if item.ClauseNumber == "" {
+				item.ClauseNumber = fmt.Sprintf("P%d", i+1)
+			}
+		}
+
+		if item.Requirement == "" {
+			return nil, fmt.Errorf("%w: item %d has no requirement", domain.ErrInvalidInput, i+1)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// ClientService gestiona las organizaciones auditadas de una consultora.
+// Cada cliente es una Organization propia: sus usuarios inician sesión en ella con rol Cliente.
+type ClientService struct {
+	repo       ports.ClientRepository
+	orgRepo    ports.OrganizationRepository
+	orgService *OrganizationService // Reutiliza el circuito de invitaciones
+}
+
+func NewClientService(repo ports.ClientRepository, orgRepo ports.OrganizationRepository, orgService *OrganizationService) *ClientService {
+	return &ClientService{
+		repo:       repo,
+		orgRepo:    orgRepo,
+		orgService: orgService,
+	}
+}
+
+func (s *ClientService) CreateClient(ctx context.Context, consultoraID, actorID, name string, input *domain.Client) (*domain.Client, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, actorID, consultoraID, domain.ActionClientManage); err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: client name is required", domain.ErrInvalidInput)
+	}
+
+	client := &domain.Client{
+		ConsultoraID: consultoraID,
+		Name:         name,
+		Sites:        normalizeList(input.Sites),
+		ContactName:  input.ContactName,
+		ContactEmail: strings.ToLower(strings.TrimSpace(input.ContactEmail)),
+		ContactPhone: input.ContactPhone,
+	}
+	if err := s.repo.CreateClient(ctx, client, &domain.Organization{Name: name}); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (s *ClientService) ListClients(ctx context.Context, consultoraID, actorID string) ([]domain.Client, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, actorID, consultoraID, domain.ActionClientManage); err != nil {
+		return nil, err
+	}
+	return s.repo.ListClients(ctx, consultoraID)
+}
+
+func (s *ClientService) GetClient(ctx context.Context, consultoraID, clientID, actorID string) (*domain.Client, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, actorID, consultoraID, domain.ActionClientManage); err != nil {
+		return nil, err
+	}
+	return s.find(ctx, consultoraID, clientID)
+}
+
+func (s *ClientService) UpdateClient(ctx context.Context, consultoraID, clientID, actorID string, input *domain.Client) (*domain.Client, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, actorID, consultoraID, domain.ActionClientManage); err != nil {
+		return nil, err
+	}
+	client, err := s.find(ctx, consultoraID, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(input.Name); name != "" {
+		client.Name = name
+	}
+	client.Sites = normalizeList(input.Sites)
+	client.ContactName = input.ContactName
+	client.ContactEmail = strings.ToLower(strings.TrimSpace(input.ContactEmail))
+	client.ContactPhone = input.ContactPhone
+
+	if err := s.repo.UpdateClient(ctx, client); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// InviteClientUser invita a la organización del cliente; sin email usa el contacto del cliente
+func (s *ClientService) InviteClientUser(ctx context.Context, consultoraID, clientID, actorID, email string) (*domain.Invitation, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, actorID, consultoraID, domain.ActionClientManage); err != nil {
+		return nil, err
+	}
+	client, err := s.find(ctx, consultoraID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if email == "" {
+		email = client.ContactEmail
+	}
+	return s.orgService.invite(ctx, email, domain.RoleCliente, client.ClientOrgID, actorID)
+}
+
+func (s *ClientService) find(ctx context.Context, consultoraID, clientID string) (*domain.Client, error) {
+	client, err := s.repo.FindClient(ctx, consultoraID, clientID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	return client, nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// allowedEvidenceTypes: tipos MIME aceptados, detectados por contenido (no por la cabecera del cliente).
+// Los formatos Office (docx/xlsx) se detectan como application/zip.
+var allowedEvidenceTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+	"application/zip": true,
+	"text/plain":      true,
+}
+
+type EvidenceService struct {
+	repo        ports.EvidenceRepository
+	auditRepo   ports.AuditRepository
+	findingRepo ports.FindingRepository
+	store       ports.BlobStore
+	maxSize     int64
+}
+
+func NewEvidenceService(repo ports.EvidenceRepository, auditRepo ports.AuditRepository, findingRepo ports.FindingRepository, store ports.BlobStore, maxSize int64) *EvidenceService {
+	return &EvidenceService{
+		repo:        repo,
+		auditRepo:   auditRepo,
+		findingRepo: findingRepo,
+		store:       store,
+		maxSize:     maxSize,
+	}
+}
+
+// UploadEvidence recibe en meta el nombre, tamaño declarado y vínculos opcionales (hallazgo / ítem de checklist)
+func (s *EvidenceService) UploadEvidence(ctx context.Context, auditID, userID string, meta *domain.Evidence, r io.Reader) (*domain.Evidence, error) {
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionEvidenceUpload); err != nil {
+		return nil, err
+	}
+	if meta.Size <= 0 {
+		return nil, fmt.Errorf("%w: empty file", domain.ErrInvalidInput)
+	}
+	if meta.Size > s.maxSize {
+		return nil, domain.ErrTooLarge
+	}
+	if meta.FindingID != "" {
+		if _, err := s.findingRepo.FindFinding(ctx, auditID, meta.FindingID); err != nil {
+			return nil, fmt.Errorf("%w: finding does not belong to this audit", domain.ErrInvalidInput)
+		}
+	}
+	if meta.ChecklistItemID != "" {
+		if _, err := s.auditRepo.FindChecklistItem(ctx, auditID, meta.ChecklistItemID); err != nil {
+			return nil, fmt.Errorf("%w: checklist item does not belong to this audit", domain.ErrInvalidInput)
+		}
+	}
+
+	// Detectar el tipo real con los primeros 512 bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	head = head[:n]
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !allowedEvidenceTypes[contentType] {
+		return nil, fmt.Errorf("%w: %s", domain.ErrUnsupported, contentType)
+	}
+
+	// Hash SHA-256 calculado mientras se transmite al BlobStore
+	hasher := sha256.New()
+	body := io.TeeReader(io.LimitReader(io.MultiReader(bytes.NewReader(head), r), meta.Size), hasher)
+
+	key := fmt.Sprintf("audits/%s/%s%s", auditID, uuid.New().String(), filepath.Ext(meta.FileName))
+	if err := s.store.Put(ctx, key, body, meta.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	evidence := &domain.Evidence{
+		AuditID:         auditID,
+		FindingID:       meta.FindingID,
+		ChecklistItemID: meta.ChecklistItemID,
+		UploadedBy:      userID,
+		FileName:        filepath.Base(meta.FileName),
+		ContentType:     contentType,
+		Size:            meta.Size,
+		SHA256:          hex.EncodeToString(hasher.Sum(nil)),
+		StorageKey:      key,
+	}
+	if err := s.repo.CreateEvidence(ctx, evidence); err != nil {
+		// No dejar blobs huérfanos si falla la metadata
+		_ = s.store.Delete(ctx, key)
+		return nil, err
+	}
+	return evidence, nil
+}
+
+func (s *EvidenceService) ListEvidence(ctx context.Context, auditID, userID string) ([]domain.Evidence, error) {
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionAuditRead); err != nil {
+		return nil, err
+	}
+	return s.repo.ListEvidenceByAudit(ctx, auditID)
+}
+
+func (s *EvidenceService) DownloadEvidence(ctx context.Context, auditID, evidenceID, userID string) (*domain.Evidence, io.ReadCloser, error) {
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionAuditRead); err != nil {
+		return nil, nil, err
+	}
+
+	evidence, err := s.repo.FindEvidence(ctx, auditID, evidenceID)
+	if err != nil {
+		return nil, nil, domain.ErrNotFound
+	}
+
+	rc, err := s.store.Get(ctx, evidence.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return evidence, rc, nil
+}
+
+func (s *EvidenceService) DeleteEvidence(ctx context.Context, auditID, evidenceID, userID string) error {
+	assignment, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionEvidenceUpload)
+	if err != nil {
+		return err
+	}
+
+	evidence, err := s.repo.FindEvidence(ctx, auditID, evidenceID)
+	if err != nil {
+		return domain.ErrNotFound
+	}
+
+	// Solo quien la subió o quien puede retirar evidencias ajenas (Auditor_Lider)
+	if evidence.UploadedBy != userID && !domain.AuditRoleAllows(assignment.RoleInAudit, domain.ActionEvidenceDeleteAny) {
+		return domain.ErrForbidden
+	}
+
+	// Soft delete de la metadata; el blob se conserva como respaldo de la trazabilidad
+	return s.repo.DeleteEvidence(ctx, auditID, evidenceID)
+}
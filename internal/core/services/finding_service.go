@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+type FindingService struct {
+	repo      ports.FindingRepository
+	auditRepo ports.AuditRepository
+	activity  *ActivityLog
+}
+
+func NewFindingService(repo ports.FindingRepository, auditRepo ports.AuditRepository, activity *ActivityLog) *FindingService {
+	return &FindingService{
+		repo:      repo,
+		auditRepo: auditRepo,
+		activity:  activity,
+	}
+}
+
+func (s *FindingService) authorize(ctx context.Context, auditID, userID string, action domain.Action) error {
+	_, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, action)
+	return err
+}
+
+func (s *FindingService) validate(ctx context.Context, auditID string, input *domain.Finding) error {
+	if !input.Severity.IsValid() {
+		return fmt.Errorf("%w: severity must be Mayor, Menor, Observación or Oportunidad_Mejora", domain.ErrInvalidInput)
+	}
+	if input.Description == "" {
+		return fmt.Errorf("%w: description is required", domain.ErrInvalidInput)
+	}
+	if input.ChecklistItemID != "" {
+		if _, err := s.auditRepo.FindChecklistItem(ctx, auditID, input.ChecklistItemID); err != nil {
+			return fmt.Errorf("%w: checklist item does not belong to this audit", domain.ErrInvalidInput)
+		}
+	}
+	return nil
+}
+
+func (s *FindingService) CreateFinding(ctx context.Context, auditID, userID string, input *domain.Finding) (*domain.Finding, error) {
+	if err := s.authorize(ctx, auditID, userID, domain.ActionFindingWrite); err != nil {
+		return nil, err
+	}
+	if err := s.validate(ctx, auditID, input); err != nil {
+		return nil, err
+	}
+
+	finding := &domain.Finding{
+		AuditID:         auditID,
+		ChecklistItemID: input.ChecklistItemID,
+		Severity:        input.Severity,
+		Description:     input.Description,
+		EvidenceRef:     input.EvidenceRef,
+		ReportedBy:      userID,
+	}
+	if err := s.repo.CreateFinding(ctx, finding); err != nil {
+		return nil, err
+	}
+	s.record(ctx, auditID, userID, finding.ID, domain.ActivityCreate, nil, finding)
+	return finding, nil
+}
+
+func (s *FindingService) ListFindings(ctx context.Context, auditID, userID string) ([]domain.Finding, error) {
+	if err := s.authorize(ctx, auditID, userID, domain.ActionAuditRead); err != nil {
+		return nil, err
+	}
+	return s.repo.ListFindingsByAudit(ctx, auditID)
+}
+
+func (s *FindingService) GetFinding(ctx context.Context, auditID, findingID, userID string) (*domain.Finding, error) {
+	if err := s.authorize(ctx, auditID, userID, domain.ActionAuditRead); err != nil {
+		return nil, err
+	}
+	finding, err := s.repo.FindFinding(ctx, auditID, findingID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	return finding, nil
+}
+
+func (s *FindingService) UpdateFinding(ctx context.Context, auditID, findingID, userID string, input *domain.Finding) (*domain.Finding, error) {
+	if err := s.authorize(ctx, auditID, userID, domain.ActionFindingWrite); err != nil {
+		return nil, err
+	}
+	if err := s.validate(ctx, auditID, input); err != nil {
+		return nil, err
+	}
+
+	finding, err := s.repo.FindFinding(ctx, auditID, findingID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	// ReportedBy se conserva: identifica a quien levantó el hallazgo
+	before := *finding
+	finding.ChecklistItemID = input.ChecklistItemID
+	finding.Severity = input.Severity
+	finding.Description = input.Description
+	finding.EvidenceRef = input.EvidenceRef
+
+	if err := s.repo.UpdateFinding(ctx, finding); err != nil {
+		return nil, err
+	}
+	s.record(ctx, auditID, userID, finding.ID, domain.ActivityUpdate, &before, finding)
+	return finding, nil
+}
+
+func (s *FindingService) DeleteFinding(ctx context.Context, auditID, findingID, userID string) error {
+	if err := s.authorize(ctx, auditID, userID, domain.ActionFindingWrite); err != nil {
+		return err
+	}
+	finding, err := s.repo.FindFinding(ctx, auditID, findingID)
+	if err != nil {
+		return domain.ErrNotFound
+	}
+	if err := s.repo.DeleteFinding(ctx, auditID, findingID); err != nil {
+		return err
+	}
+	s.record(ctx, auditID, userID, findingID, domain.ActivityDelete, finding, nil)
+	return nil
+}
+
+// record anota el cambio en la bitácora de la organización dueña de la auditoría
+func (s *FindingService) record(ctx context.Context, auditID, userID, findingID string, action domain.ActivityAction, before, after interface{}) {
+	if audit, err := s.auditRepo.GetAuditByID(ctx, auditID); err == nil {
+		s.activity.record(ctx, audit.OrgOwnerID, userID, domain.EntityFinding, findingID, action, before, after)
+	}
+}
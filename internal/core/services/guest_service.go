@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/auth"
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// guestSessionTTL acota la vida de una sesión de invitado aunque el enlace dure más
+const guestSessionTTL = 2 * time.Hour
+
+// GuestService resuelve los enlaces temporales de participantes externos
+type GuestService struct {
+	repo         ports.AuditRepository
+	auditService ports.AuditService // La aceptación implícita pasa por el mismo flujo que la explícita
+	jwtAdapter   *auth.JWTAdapter
+	tokenSecret  string
+}
+
+func NewGuestService(repo ports.AuditRepository, auditService ports.AuditService, jwtAdapter *auth.JWTAdapter) *GuestService {
+	return &GuestService{
+		repo:         repo,
+		auditService: auditService,
+		jwtAdapter:   jwtAdapter,
+		tokenSecret:  jwtAdapter.Secret,
+	}
+}
+
+// OpenLink devuelve la vista acotada de la auditoría sin consumir el enlace: los
+// escáneres de correo y las vistas previas también hacen GET sobre la URL
+func (s *GuestService) OpenLink(ctx context.Context, token string) (*domain.PublicAuditView, error) {
+	link, audit, err := s.findLink(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return publicView(link, audit), nil
+}
+
+// StartGuestSession canjea el enlace por un token de invitado limitado a sus scopes.
+// Usar el enlace equivale a aceptar la asignación pendiente. El uso se cuenta recién
+// cuando la sesión quedó emitida: un intento fallido no consume el enlace.
+func (s *GuestService) StartGuestSession(ctx context.Context, token string) (string, *domain.PublicAuditView, error) {
+	link, audit, err := s.findLink(ctx, token)
+	if err != nil {
+		return "", nil, err
+	}
+
+	assignment, err := s.repo.FindAuditAssignment(ctx, link.AuditID, link.UserID)
+	if err != nil || !assignment.IsActive || assignment.AcceptanceStatus == domain.AcceptRechazado {
+		return "", nil, domain.ErrNotAssigned
+	}
+	if assignment.AcceptanceStatus == domain.AcceptPendiente {
+		if _, err := s.auditService.RespondToAssignment(ctx, link.AuditID, link.UserID, true, ""); err != nil {
+			return "", nil, err
+		}
+	}
+
+	expiresAt := time.Now().Add(guestSessionTTL)
+	if link.ExpiresAt.Before(expiresAt) {
+		expiresAt = link.ExpiresAt
+	}
+
+	scopes := link.ScopeList()
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	guestToken, err := s.jwtAdapter.GenerateGuestToken(link.UserID, link.AuditID, link.ID, names, expiresAt)
+	if err != nil {
+		return "", nil, err
+	}
+	// El conteo es atómico: si otro canje agotó el enlace mientras tanto, el token se descarta
+	if err := s.repo.RegisterTemporaryLinkUse(ctx, link.ID); err != nil {
+		return "", nil, err
+	}
+	return guestToken, publicView(link, audit), nil
+}
+
+// findLink valida el enlace y su auditoría sin contar el uso
+func (s *GuestService) findLink(ctx context.Context, token string) (*domain.TemporaryLink, *domain.Audit, error) {
+	link, err := s.repo.FindTemporaryLinkByHash(ctx, hashToken(s.tokenSecret, token))
+	if err != nil || !link.IsUsable(time.Now()) {
+		return nil, nil, fmt.Errorf("%w: invalid or expired link", domain.ErrForbidden)
+	}
+
+	audit, err := s.repo.GetAuditByID(ctx, link.AuditID)
+	if err != nil {
+		return nil, nil, domain.ErrNotFound
+	}
+	if audit.Status == domain.AuditFinalizada {
+		return nil, nil, domain.ErrAuditClosed
+	}
+	return link, audit, nil
+}
+
+func publicView(link *domain.TemporaryLink, audit *domain.Audit) *domain.PublicAuditView {
+	return &domain.PublicAuditView{
+		ID:        audit.ID,
+		Title:     audit.Title,
+		Status:    audit.Status,
+		Scopes:    link.ScopeList(),
+		ExpiresAt: link.ExpiresAt,
+	}
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/auth"
+	"github.com/RiosHectorM/iso-stack/internal/adapters/repository"
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+// chanNotifier entrega las notificaciones por un canal: el Mailer las despacha en segundo plano
+type chanNotifier chan domain.Notification
+
+func (c chanNotifier) Notify(n domain.Notification) error {
+	c <- n
+	return nil
+}
+
+type guestFixture struct {
+	repo    *repository.MemoryRepository
+	guests  *GuestService
+	sent    chanNotifier
+	audit   *domain.Audit
+	guestID string
+	token   string
+	link    *domain.TemporaryLink
+}
+
+func newGuestFixture(t *testing.T) *guestFixture {
+	t.Helper()
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+	sent := make(chanNotifier, 10)
+	const secret = "test-secret"
+
+	lead := &domain.User{Email: "lead@example.com", Password: "x"}
+	org := &domain.Organization{Name: "Consultora"}
+	if err := repo.CreateUserWithOrg(ctx, lead, org, &domain.UserOrganization{RoleDefault: domain.RoleAuditorLider, JoinedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	guest := &domain.User{Email: "guest@example.com", Password: "x"}
+	if err := repo.CreateUserAndAddToOrg(ctx, guest, &domain.UserOrganization{OrganizationID: org.ID, RoleDefault: domain.RoleObservador, JoinedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	audit := &domain.Audit{Title: "Auditoría", OrgOwnerID: org.ID, Status: domain.AuditEnCurso}
+	if err := repo.CreateAudit(ctx, audit); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range []*domain.AuditAssignment{
+		{AuditID: audit.ID, UserID: lead.ID, RoleInAudit: domain.RoleAuditorLider, AcceptanceStatus: domain.AcceptAceptado},
+		{AuditID: audit.ID, UserID: guest.ID, RoleInAudit: domain.RoleObservador, AcceptanceStatus: domain.AcceptPendiente},
+	} {
+		if err := repo.AssignUserToAudit(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	audits := NewAuditService(repo, repo, repo, nil, nil, NewMailer(sent, "es", ""), secret, time.Hour, nil)
+	link, url, err := audits.issueTemporaryLink(ctx, audit, guest.ID, lead.ID, []domain.LinkScope{domain.ScopeView}, time.Hour, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	guests := NewGuestService(repo, audits, &auth.JWTAdapter{Secret: secret})
+	return &guestFixture{
+		repo: repo, guests: guests, sent: sent, audit: audit, guestID: guest.ID,
+		token: strings.TrimPrefix(url, "/public/access/"), link: link,
+	}
+}
+
+func (f *guestFixture) useCount(t *testing.T) int {
+	t.Helper()
+	link, err := f.repo.FindTemporaryLink(context.Background(), f.link.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return link.UseCount
+}
+
+func TestStartGuestSessionAcceptsAssignment(t *testing.T) {
+	f := newGuestFixture(t)
+	ctx := context.Background()
+
+	token, view, err := f.guests.StartGuestSession(ctx, f.token)
+	if err != nil {
+		t.Fatalf("StartGuestSession: %v", err)
+	}
+	if token == "" || view.ID != f.audit.ID {
+		t.Fatalf("unexpected session: token=%q view=%+v", token, view)
+	}
+	assignment, err := f.repo.FindAuditAssignment(ctx, f.audit.ID, f.guestID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assignment.AcceptanceStatus != domain.AcceptAceptado || assignment.RespondedAt == nil {
+		t.Fatalf("assignment not accepted: %+v", assignment)
+	}
+	if got := f.useCount(t); got != 1 {
+		t.Fatalf("use count = %d, want 1", got)
+	}
+
+	select {
+	case n := <-f.sent:
+		if n.To != "lead@example.com" || n.Kind != domain.NotifyAssignmentReply || n.Data["response"] != string(domain.AcceptAceptado) {
+			t.Fatalf("unexpected notification: %+v", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the lead was not notified")
+	}
+
+	// MaxUses = 1: el enlace ya se consumió
+	if _, _, err := f.guests.StartGuestSession(ctx, f.token); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("second use: got %v, want ErrForbidden", err)
+	}
+}
+
+func TestStartGuestSessionFailureDoesNotConsumeLink(t *testing.T) {
+	f := newGuestFixture(t)
+	ctx := context.Background()
+
+	assignment, err := f.repo.FindAuditAssignment(ctx, f.audit.ID, f.guestID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assignment.IsActive = false
+	if err := f.repo.UpdateAuditAssignment(ctx, assignment); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := f.guests.StartGuestSession(ctx, f.token); !errors.Is(err, domain.ErrNotAssigned) {
+		t.Fatalf("got %v, want ErrNotAssigned", err)
+	}
+	if got := f.useCount(t); got != 0 {
+		t.Fatalf("use count = %d, want 0", got)
+	}
+}
+
+func TestOpenLinkDoesNotConsumeLink(t *testing.T) {
+	f := newGuestFixture(t)
+	ctx := context.Background()
+
+	// MaxUses = 1: abrir el enlace (o que lo abra un escáner de correo) no puede agotarlo
+	for i := 0; i < 2; i++ {
+		if _, err := f.guests.OpenLink(ctx, f.token); err != nil {
+			t.Fatalf("OpenLink: %v", err)
+		}
+	}
+	if got := f.useCount(t); got != 0 {
+		t.Fatalf("use count after OpenLink = %d, want 0", got)
+	}
+	if _, _, err := f.guests.StartGuestSession(ctx, f.token); err != nil {
+		t.Fatalf("StartGuestSession after OpenLink: %v", err)
+	}
+	if got := f.useCount(t); got != 1 {
+		t.Fatalf("use count = %d, want 1", got)
+	}
+}
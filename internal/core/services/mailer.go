@@ -0,0 +1,43 @@
+package services
+
+import (
+	"log"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// Mailer agrupa el Notifier con los datos comunes a todos los mensajes salientes
+type Mailer struct {
+	notifier ports.Notifier
+	locale   string
+	baseURL  string // URL pública del frontend, para armar enlaces
+}
+
+func NewMailer(notifier ports.Notifier, locale, baseURL string) *Mailer {
+	return &Mailer{
+		notifier: notifier,
+		locale:   locale,
+		baseURL:  baseURL,
+	}
+}
+
+// send despacha en segundo plano: un fallo de notificación nunca revierte la operación de negocio
+func (m *Mailer) send(to string, kind domain.NotificationKind, data map[string]string) {
+	if m == nil || m.notifier == nil || to == "" {
+		return
+	}
+	n := domain.Notification{To: to, Locale: m.locale, Kind: kind, Data: data}
+	go func() {
+		if err := m.notifier.Notify(n); err != nil {
+			log.Printf("error enviando notificación %s a %s: %v", kind, to, err)
+		}
+	}()
+}
+
+func (m *Mailer) link(path string) string {
+	if m == nil {
+		return path
+	}
+	return m.baseURL + path
+}
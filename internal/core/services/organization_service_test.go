@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/repository"
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+// Las invitaciones no están en el repositorio en memoria: estas pruebas usan SQLite
+func newSQLiteRepo(t *testing.T) *repository.SQLiteRepository {
+	t.Helper()
+	repo, err := repository.OpenSQLite(filepath.Join(t.TempDir(), "db.sqlite"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if conn, err := repo.DB.DB(); err == nil {
+			conn.Close()
+		}
+	})
+	return repo
+}
+
+// invitationToken recupera el token en claro del correo de invitación
+func invitationToken(t *testing.T, sent chanNotifier) string {
+	t.Helper()
+	select {
+	case n := <-sent:
+		link, err := url.Parse(n.Data["accept_url"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return link.Query().Get("token")
+	case <-time.After(time.Second):
+		t.Fatal("the invitation was not sent")
+		return ""
+	}
+}
+
+func TestInvitationCannotReactivateMember(t *testing.T) {
+	ctx := context.Background()
+	repo := newSQLiteRepo(t)
+	sent := make(chanNotifier, 10)
+	const secret = "test-secret"
+	orgs := NewOrganizationService(repo, repo, repo, NewMailer(sent, "es", ""), secret, time.Hour, NewActivityLog(repo))
+
+	owner := &domain.User{Email: "owner@example.com", Password: "x"}
+	org := &domain.Organization{Name: "Consultora"}
+	if err := repo.CreateUserWithOrg(ctx, owner, org, &domain.UserOrganization{RoleDefault: domain.RoleConsultora, JoinedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	member := &domain.User{Email: "member@example.com", Password: "x"}
+	if err := repo.CreateUserWithOrg(ctx, member, &domain.Organization{Name: "Otra"}, &domain.UserOrganization{RoleDefault: domain.RoleConsultora, JoinedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Una sola invitación pendiente por persona y organización
+	if _, err := orgs.InviteStaff(ctx, member.Email, string(domain.RoleAuxiliar), org.ID, owner.ID); err != nil {
+		t.Fatalf("InviteStaff: %v", err)
+	}
+	token := invitationToken(t, sent)
+	if _, err := orgs.InviteStaff(ctx, strings.ToUpper(member.Email), string(domain.RoleAuditorLider), org.ID, owner.ID); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("second invitation: got %v, want ErrConflict", err)
+	}
+
+	if err := orgs.AcceptInvitation(ctx, token, ""); err != nil {
+		t.Fatalf("AcceptInvitation: %v", err)
+	}
+	if err := orgs.UpdateStaffStatus(ctx, member.ID, org.ID, string(domain.MemberInactivo), owner.ID); err != nil {
+		t.Fatalf("UpdateStaffStatus: %v", err)
+	}
+
+	// Una invitación que quedó pendiente de antes no puede devolverle el acceso
+	stale := &domain.Invitation{
+		OrganizationID: org.ID,
+		Email:          member.Email,
+		Role:           domain.RoleAuditorLider,
+		TokenHash:      hashToken(secret, "stale-token"),
+		InvitedBy:      owner.ID,
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+	if err := repo.CreateInvitation(ctx, stale); err != nil {
+		t.Fatal(err)
+	}
+	if err := orgs.AcceptInvitation(ctx, "stale-token", ""); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("stale invitation: got %v, want ErrConflict", err)
+	}
+
+	membership, err := repo.FindUserOrg(ctx, member.ID, org.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if membership.Status != domain.MemberInactivo || membership.RoleDefault != domain.RoleAuxiliar {
+		t.Fatalf("membership changed: %+v", membership)
+	}
+	if inv, err := repo.FindInvitation(ctx, org.ID, stale.ID); err != nil || inv.AcceptedAt != nil {
+		t.Fatalf("stale invitation was consumed: %+v, %v", inv, err)
+	}
+}
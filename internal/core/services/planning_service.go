@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// PlanningService arma el plan de auditoría (ISO 19011 6.3.2): ventana de fechas y
+// agenda de sesiones por proceso, evitando que un auditor quede en dos sesiones a la vez
+type PlanningService struct {
+	repo      ports.PlanningRepository
+	auditRepo ports.AuditRepository
+}
+
+func NewPlanningService(repo ports.PlanningRepository, auditRepo ports.AuditRepository) *PlanningService {
+	return &PlanningService{
+		repo:      repo,
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *PlanningService) GetPlan(ctx context.Context, auditID, userID string) (*domain.AuditPlan, error) {
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionAuditRead); err != nil {
+		return nil, err
+	}
+	return s.plan(ctx, auditID)
+}
+
+// SetSchedule fija (o borra, con ambas fechas nil) la ventana planificada.
+// No se permite achicarla dejando sesiones afuera.
+func (s *PlanningService) SetSchedule(ctx context.Context, auditID, userID string, plannedStart, plannedEnd *time.Time) (*domain.AuditPlan, error) {
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionAuditPlan); err != nil {
+		return nil, err
+	}
+	if (plannedStart == nil) != (plannedEnd == nil) {
+		return nil, fmt.Errorf("%w: planned_start and planned_end must be set together", domain.ErrInvalidInput)
+	}
+	if plannedStart != nil && !plannedEnd.After(*plannedStart) {
+		return nil, fmt.Errorf("%w: planned_end must be after planned_start", domain.ErrInvalidInput)
+	}
+
+	if plannedStart != nil {
+		sessions, err := s.repo.ListAuditSessions(ctx, auditID)
+		if err != nil {
+			return nil, err
+		}
+		for _, session := range sessions {
+			if session.StartsAt.Before(*plannedStart) || session.EndsAt.After(*plannedEnd) {
+				return nil, fmt.Errorf("%w: session %s (%s) falls outside the new schedule", domain.ErrInvalidInput, session.ID, session.Process)
+			}
+		}
+	}
+
+	if err := s.repo.UpdateAuditSchedule(ctx, auditID, plannedStart, plannedEnd); err != nil {
+		return nil, err
+	}
+	return s.plan(ctx, auditID)
+}
+
+func (s *PlanningService) AddSession(ctx context.Context, auditID, userID string, input *domain.AuditSession) (*domain.AuditSession, error) {
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionAuditPlan); err != nil {
+		return nil, err
+	}
+
+	session := &domain.AuditSession{
+		AuditID:   auditID,
+		StartsAt:  input.StartsAt,
+		EndsAt:    input.EndsAt,
+		Site:      input.Site,
+		Process:   input.Process,
+		Auditee:   input.Auditee,
+		AuditorID: input.AuditorID,
+		Notes:     input.Notes,
+		CreatedBy: userID,
+	}
+	if err := s.validate(ctx, session); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateAuditSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *PlanningService) UpdateSession(ctx context.Context, auditID, sessionID, userID string, input *domain.AuditSession) (*domain.AuditSession, error) {
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionAuditPlan); err != nil {
+		return nil, err
+	}
+	session, err := s.repo.FindAuditSession(ctx, auditID, sessionID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	session.StartsAt = input.StartsAt
+	session.EndsAt = input.EndsAt
+	session.Site = input.Site
+	session.Process = input.Process
+	session.Auditee = input.Auditee
+	session.AuditorID = input.AuditorID
+	session.Notes = input.Notes
+
+	if err := s.validate(ctx, session); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateAuditSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *PlanningService) DeleteSession(ctx context.Context, auditID, sessionID, userID string) error {
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionAuditPlan); err != nil {
+		return err
+	}
+	if _, err := s.repo.FindAuditSession(ctx, auditID, sessionID); err != nil {
+		return domain.ErrNotFound
+	}
+	return s.repo.DeleteAuditSession(ctx, auditID, sessionID)
+}
+
+// ListConflicts revisa la agenda contra las de otras auditorías: una sesión válida al
+// crearla puede quedar en conflicto si después se agenda al mismo auditor en otra parte
+func (s *PlanningService) ListConflicts(ctx context.Context, auditID, userID string) ([]domain.ScheduleConflict, error) {
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionAuditRead); err != nil {
+		return nil, err
+	}
+	audit, err := s.auditRepo.GetAuditByID(ctx, auditID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	sessions, err := s.repo.ListAuditSessions(ctx, auditID)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := map[string]string{audit.ID: audit.OrgOwnerID}
+	conflicts := []domain.ScheduleConflict{}
+	for _, session := range sessions {
+		overlapping, err := s.repo.FindOverlappingSessions(ctx, session.AuditorID, session.StartsAt, session.EndsAt, session.ID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range overlapping {
+			other := &overlapping[i]
+			conflict := domain.ScheduleConflict{Session: session, With: other}
+			if !s.sameOwner(ctx, owners, audit.OrgOwnerID, other.AuditID) {
+				conflict = domain.ScheduleConflict{Session: session, Busy: &domain.BusySlot{StartsAt: other.StartsAt, EndsAt: other.EndsAt}}
+			}
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	return conflicts, nil
+}
+
+// sameOwner indica si auditID es de ownerID. Un auditor puede trabajar para varias
+// consultoras: de las auditorías ajenas no se expone nada más que el horario.
+// owners cachea el dueño de cada auditoría ya consultada.
+func (s *PlanningService) sameOwner(ctx context.Context, owners map[string]string, ownerID, auditID string) bool {
+	owner, ok := owners[auditID]
+	if !ok {
+		if other, err := s.auditRepo.GetAuditByID(ctx, auditID); err == nil {
+			owner = other.OrgOwnerID
+		}
+		owners[auditID] = owner
+	}
+	return owner == ownerID
+}
+
+func (s *PlanningService) validate(ctx context.Context, session *domain.AuditSession) error {
+	if session.Process == "" {
+		return fmt.Errorf("%w: process is required", domain.ErrInvalidInput)
+	}
+	if session.StartsAt.IsZero() || !session.EndsAt.After(session.StartsAt) {
+		return fmt.Errorf("%w: ends_at must be after starts_at", domain.ErrInvalidInput)
+	}
+
+	audit, err := s.auditRepo.GetAuditByID(ctx, session.AuditID)
+	if err != nil {
+		return domain.ErrNotFound
+	}
+	if audit.PlannedStart != nil && (session.StartsAt.Before(*audit.PlannedStart) || session.EndsAt.After(*audit.PlannedEnd)) {
+		return fmt.Errorf("%w: session must fall within the planned schedule", domain.ErrInvalidInput)
+	}
+
+	// El auditor tiene que estar asignado, no haber rechazado y poder auditar (no Observador)
+	assignment, err := s.auditRepo.FindAuditAssignment(ctx, session.AuditID, session.AuditorID)
+	if err != nil || !assignment.IsActive || assignment.AcceptanceStatus == domain.AcceptRechazado {
+		return fmt.Errorf("%w: auditor is not assigned to this audit", domain.ErrInvalidInput)
+	}
+	if assignment.RoleInAudit == domain.RoleObservador {
+		return fmt.Errorf("%w: an Observador cannot conduct a session", domain.ErrInvalidInput)
+	}
+
+	overlapping, err := s.repo.FindOverlappingSessions(ctx, session.AuditorID, session.StartsAt, session.EndsAt, session.ID)
+	if err != nil {
+		return err
+	}
+	if len(overlapping) > 0 {
+		other := overlapping[0]
+		if !s.sameOwner(ctx, map[string]string{}, audit.OrgOwnerID, other.AuditID) {
+			return fmt.Errorf("%w: %s to %s (another organization)", domain.ErrDoubleBooked,
+				other.StartsAt.Format(time.RFC3339), other.EndsAt.Format(time.RFC3339))
+		}
+		return fmt.Errorf("%w: %s to %s (audit %s, %s)", domain.ErrDoubleBooked,
+			other.StartsAt.Format(time.RFC3339), other.EndsAt.Format(time.RFC3339), other.AuditID, other.Process)
+	}
+	return nil
+}
+
+func (s *PlanningService) plan(ctx context.Context, auditID string) (*domain.AuditPlan, error) {
+	audit, err := s.auditRepo.GetAuditByID(ctx, auditID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	sessions, err := s.repo.ListAuditSessions(ctx, auditID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AuditPlan{
+		AuditID:      audit.ID,
+		PlannedStart: audit.PlannedStart,
+		PlannedEnd:   audit.PlannedEnd,
+		Sessions:     sessions,
+	}, nil
+}
@@ -0,0 +1,343 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+	"github.com/google/uuid"
+)
+
+// spawnClaimPrefix marca la entrada mientras SpawnAudit crea su auditoría
+const spawnClaimPrefix = "spawning:"
+
+// ProgrammeService gestiona el programa de auditorías plurianual de la organización
+// y su cobertura de procesos y cláusulas
+type ProgrammeService struct {
+	repo         ports.ProgrammeRepository
+	auditRepo    ports.AuditRepository
+	orgRepo      ports.OrganizationRepository
+	auditService ports.AuditService
+}
+
+func NewProgrammeService(repo ports.ProgrammeRepository, auditRepo ports.AuditRepository, orgRepo ports.OrganizationRepository, auditService ports.AuditService) *ProgrammeService {
+	return &ProgrammeService{
+		repo:         repo,
+		auditRepo:    auditRepo,
+		orgRepo:      orgRepo,
+		auditService: auditService,
+	}
+}
+
+func (s *ProgrammeService) CreateProgramme(ctx context.Context, orgID, userID string, input *domain.AuditProgramme) (*domain.AuditProgramme, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionProgrammeEdit); err != nil {
+		return nil, err
+	}
+	if err := validateProgramme(input); err != nil {
+		return nil, err
+	}
+
+	programme := &domain.AuditProgramme{
+		OrganizationID: orgID,
+		Name:           input.Name,
+		StartYear:      input.StartYear,
+		EndYear:        input.EndYear,
+		Objectives:     input.Objectives,
+		Processes:      normalizeList(input.Processes),
+		Clauses:        normalizeList(input.Clauses),
+		CreatedBy:      userID,
+	}
+	if err := s.repo.CreateProgramme(ctx, programme); err != nil {
+		return nil, err
+	}
+	return programme, nil
+}
+
+func (s *ProgrammeService) ListProgrammes(ctx context.Context, orgID, userID string) ([]domain.AuditProgramme, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionProgrammeRead); err != nil {
+		return nil, err
+	}
+	return s.repo.ListProgrammes(ctx, orgID)
+}
+
+func (s *ProgrammeService) GetProgramme(ctx context.Context, orgID, programmeID, userID string) (*domain.AuditProgramme, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionProgrammeRead); err != nil {
+		return nil, err
+	}
+	return s.find(ctx, orgID, programmeID)
+}
+
+// UpdateProgramme no permite achicar los años dejando entradas fuera del rango
+func (s *ProgrammeService) UpdateProgramme(ctx context.Context, orgID, programmeID, userID string, input *domain.AuditProgramme) (*domain.AuditProgramme, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionProgrammeEdit); err != nil {
+		return nil, err
+	}
+	if err := validateProgramme(input); err != nil {
+		return nil, err
+	}
+	programme, err := s.find(ctx, orgID, programmeID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.ListProgrammeEntries(ctx, programmeID)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if year := periodYear(entry.Period); year < input.StartYear || year > input.EndYear {
+			return nil, fmt.Errorf("%w: entry %q (%s) falls outside the new years", domain.ErrInvalidInput, entry.Title, entry.Period)
+		}
+	}
+
+	programme.Name = input.Name
+	programme.StartYear = input.StartYear
+	programme.EndYear = input.EndYear
+	programme.Objectives = input.Objectives
+	programme.Processes = normalizeList(input.Processes)
+	programme.Clauses = normalizeList(input.Clauses)
+
+	if err := s.repo.UpdateProgramme(ctx, programme); err != nil {
+		return nil, err
+	}
+	return programme, nil
+}
+
+func (s *ProgrammeService) AddEntry(ctx context.Context, orgID, programmeID, userID string, input *domain.ProgrammeEntry) (*domain.ProgrammeEntry, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionProgrammeEdit); err != nil {
+		return nil, err
+	}
+	programme, err := s.find(ctx, orgID, programmeID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Title == "" {
+		return nil, fmt.Errorf("%w: title is required", domain.ErrInvalidInput)
+	}
+	if year := periodYear(input.Period); year < programme.StartYear || year > programme.EndYear {
+		return nil, fmt.Errorf("%w: period must start with a year between %d and %d (e.g. %d-Q1)",
+			domain.ErrInvalidInput, programme.StartYear, programme.EndYear, programme.StartYear)
+	}
+
+	entry := &domain.ProgrammeEntry{
+		ProgrammeID: programmeID,
+		Period:      input.Period,
+		Title:       input.Title,
+		Processes:   normalizeList(input.Processes),
+		Clauses:     normalizeList(input.Clauses),
+		ClientID:    input.ClientID,
+	}
+	if err := s.repo.CreateProgrammeEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *ProgrammeService) ListEntries(ctx context.Context, orgID, programmeID, userID string) ([]domain.ProgrammeEntry, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionProgrammeRead); err != nil {
+		return nil, err
+	}
+	if _, err := s.find(ctx, orgID, programmeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListProgrammeEntries(ctx, programmeID)
+}
+
+// DeleteEntry solo aplica a entradas que todavía no generaron una auditoría
+func (s *ProgrammeService) DeleteEntry(ctx context.Context, orgID, programmeID, entryID, userID string) error {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionProgrammeEdit); err != nil {
+		return err
+	}
+	if _, err := s.find(ctx, orgID, programmeID); err != nil {
+		return err
+	}
+	entry, err := s.repo.FindProgrammeEntry(ctx, programmeID, entryID)
+	if err != nil {
+		return domain.ErrNotFound
+	}
+	if entry.AuditID != "" {
+		return fmt.Errorf("%w: entry already has audit %s", domain.ErrConflict, entry.AuditID)
+	}
+	return s.repo.DeleteProgrammeEntry(ctx, programmeID, entryID)
+}
+
+// SpawnAudit crea la auditoría concreta de la entrada; quien la genera queda como Auditor_Lider
+func (s *ProgrammeService) SpawnAudit(ctx context.Context, orgID, programmeID, entryID, userID string) (*domain.Audit, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionProgrammeEdit); err != nil {
+		return nil, err
+	}
+	if _, err := s.find(ctx, orgID, programmeID); err != nil {
+		return nil, err
+	}
+	entry, err := s.repo.FindProgrammeEntry(ctx, programmeID, entryID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	if entry.AuditID != "" {
+		return nil, fmt.Errorf("%w: entry already has audit %s", domain.ErrConflict, entry.AuditID)
+	}
+
+	// Reservar la entrada antes de crear la auditoría: de dos pedidos simultáneos solo uno la
+	// obtiene. Mientras dura, la reserva cuenta como auditoría asignada (DeleteEntry la rechaza).
+	claim := spawnClaimPrefix + uuid.New().String()
+	if err := s.repo.SwapProgrammeEntryAudit(ctx, programmeID, entryID, "", claim); err != nil {
+		return nil, err
+	}
+
+	audit, err := s.auditService.CreateAudit(ctx, entry.Title, orgID, userID, entry.ClientID, "")
+	if err != nil {
+		if releaseErr := s.repo.SwapProgrammeEntryAudit(ctx, programmeID, entryID, claim, ""); releaseErr != nil {
+			log.Printf("no se pudo liberar la entrada %s del programa %s: %v", entryID, programmeID, releaseErr)
+		}
+		return nil, err
+	}
+	if err := s.repo.SwapProgrammeEntryAudit(ctx, programmeID, entryID, claim, audit.ID); err != nil {
+		return nil, err
+	}
+	return audit, nil
+}
+
+// Coverage marca cada proceso y cláusula del programa como Sin_Cubrir, Planificada
+// (alguna entrada la incluye) o Auditada. Un proceso está auditado cuando una entrada
+// que lo incluye generó una auditoría Finalizada; una cláusula, cuando figura respondida
+// en el checklist de alguna de las auditorías generadas.
+func (s *ProgrammeService) Coverage(ctx context.Context, orgID, programmeID, userID string) (*domain.ProgrammeCoverage, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionProgrammeRead); err != nil {
+		return nil, err
+	}
+	programme, err := s.find(ctx, orgID, programmeID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.repo.ListProgrammeEntries(ctx, programmeID)
+	if err != nil {
+		return nil, err
+	}
+
+	plannedProcesses := map[string][]string{}
+	plannedClauses := map[string][]string{}
+	auditedProcesses := map[string]bool{}
+	auditedClauses := map[string]bool{}
+
+	for _, entry := range entries {
+		for _, p := range domain.SplitList(entry.Processes) {
+			plannedProcesses[p] = append(plannedProcesses[p], entry.Period)
+		}
+		for _, c := range domain.SplitList(entry.Clauses) {
+			plannedClauses[c] = append(plannedClauses[c], entry.Period)
+		}
+		if entry.AuditID == "" {
+			continue
+		}
+
+		audit, err := s.auditRepo.GetAuditByID(ctx, entry.AuditID)
+		if err != nil {
+			continue // La auditoría pudo borrarse: cuenta solo como planificada
+		}
+		if audit.Status == domain.AuditFinalizada {
+			for _, p := range domain.SplitList(entry.Processes) {
+				auditedProcesses[p] = true
+			}
+		}
+		items, err := s.auditRepo.GetChecklistByAuditID(ctx, entry.AuditID)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if item.Answer != domain.AnswerPendiente {
+				auditedClauses[item.Standard+" "+item.ClauseNumber] = true
+			}
+		}
+	}
+
+	coverage := &domain.ProgrammeCoverage{
+		ProgrammeID: programme.ID,
+		Processes:   []domain.CoverageItem{},
+		Clauses:     []domain.CoverageItem{},
+		ProcessGaps: []string{},
+		ClauseGaps:  []string{},
+		Undeclared:  []string{},
+	}
+	declared := map[string]bool{}
+
+	for _, p := range domain.SplitList(programme.Processes) {
+		declared[p] = true
+		item := coverageItem(p, plannedProcesses[p], auditedProcesses[p])
+		if item.Status == domain.CoverageGap {
+			coverage.ProcessGaps = append(coverage.ProcessGaps, p)
+		}
+		coverage.Processes = append(coverage.Processes, item)
+	}
+	for _, c := range domain.SplitList(programme.Clauses) {
+		declared[c] = true
+		item := coverageItem(c, plannedClauses[c], auditedClauses[c])
+		if item.Status == domain.CoverageGap {
+			coverage.ClauseGaps = append(coverage.ClauseGaps, c)
+		}
+		coverage.Clauses = append(coverage.Clauses, item)
+	}
+
+	for _, planned := range []map[string][]string{plannedProcesses, plannedClauses} {
+		for key := range planned {
+			if !declared[key] {
+				coverage.Undeclared = append(coverage.Undeclared, key)
+			}
+		}
+	}
+	sort.Strings(coverage.Undeclared)
+	return coverage, nil
+}
+
+func (s *ProgrammeService) find(ctx context.Context, orgID, programmeID string) (*domain.AuditProgramme, error) {
+	programme, err := s.repo.FindProgramme(ctx, orgID, programmeID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	return programme, nil
+}
+
+func validateProgramme(input *domain.AuditProgramme) error {
+	if input.Name == "" {
+		return fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+	if input.StartYear < 2000 || input.EndYear < input.StartYear {
+		return fmt.Errorf("%w: end_year must be greater than or equal to start_year", domain.ErrInvalidInput)
+	}
+	return nil
+}
+
+func coverageItem(key string, periods []string, audited bool) domain.CoverageItem {
+	item := domain.CoverageItem{Key: key, Status: domain.CoverageGap, Periods: periods}
+	if item.Periods == nil {
+		item.Periods = []string{}
+	}
+	switch {
+	case audited:
+		item.Status = domain.CoverageAudited
+	case len(periods) > 0:
+		item.Status = domain.CoveragePlanned
+	}
+	return item
+}
+
+// periodYear extrae el año inicial del período ("2026-Q2" -> 2026); 0 si no es válido
+func periodYear(period string) int {
+	if len(period) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(period[:4])
+	if err != nil {
+		return 0
+	}
+	return year
+}
+
+// normalizeList limpia espacios y vacíos de una lista separada por comas
+func normalizeList(s string) string {
+	return strings.Join(domain.SplitList(s), ",")
+}
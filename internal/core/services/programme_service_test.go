@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+// De varios SpawnAudit simultáneos sobre la misma entrada, solo uno crea la auditoría
+func TestSpawnAuditOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := newSQLiteRepo(t)
+	activity := NewActivityLog(repo)
+	audits := NewAuditService(repo, repo, repo, repo, repo, nil, "test-secret", time.Hour, activity)
+	programmes := NewProgrammeService(repo, repo, repo, audits)
+
+	owner := &domain.User{Email: "owner@example.com", Password: "x"}
+	org := &domain.Organization{Name: "Consultora"}
+	if err := repo.CreateUserWithOrg(ctx, owner, org, &domain.UserOrganization{RoleDefault: domain.RoleConsultora, Status: domain.MemberActivo, JoinedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	programme, err := programmes.CreateProgramme(ctx, org.ID, owner.ID, &domain.AuditProgramme{Name: "Programa", StartYear: 2026, EndYear: 2028})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := programmes.AddEntry(ctx, org.ID, programme.ID, owner.ID, &domain.ProgrammeEntry{Period: "2026-Q4", Title: "Auditoría interna"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const calls = 5
+	var wg sync.WaitGroup
+	results := make(chan error, calls)
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := programmes.SpawnAudit(ctx, org.ID, programme.ID, entry.ID, owner.ID)
+			results <- err
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	spawned := 0
+	for err := range results {
+		switch {
+		case err == nil:
+			spawned++
+		case !errors.Is(err, domain.ErrConflict):
+			t.Fatalf("SpawnAudit: %v", err)
+		}
+	}
+	if spawned != 1 {
+		t.Fatalf("%d calls spawned an audit, want 1", spawned)
+	}
+
+	stored, err := repo.FindProgrammeEntry(ctx, programme.ID, entry.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mine, err := repo.GetAuditsByUserID(ctx, owner.ID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mine) != 1 || stored.AuditID != mine[0].ID {
+		t.Fatalf("entry audit %q, audits created %+v", stored.AuditID, mine)
+	}
+}
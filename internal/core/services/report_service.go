@@ -0,0 +1,174 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+type ReportService struct {
+	auditRepo    ports.AuditRepository
+	findingRepo  ports.FindingRepository
+	authRepo     ports.AuthRepository
+	orgRepo      ports.OrganizationRepository
+	templateRepo ports.ReportTemplateRepository
+	renderers    map[string]ports.ReportRenderer
+}
+
+func NewReportService(auditRepo ports.AuditRepository, findingRepo ports.FindingRepository, authRepo ports.AuthRepository, orgRepo ports.OrganizationRepository, templateRepo ports.ReportTemplateRepository, renderers map[string]ports.ReportRenderer) *ReportService {
+	return &ReportService{
+		auditRepo:    auditRepo,
+		findingRepo:  findingRepo,
+		authRepo:     authRepo,
+		orgRepo:      orgRepo,
+		templateRepo: templateRepo,
+		renderers:    renderers,
+	}
+}
+
+func (s *ReportService) GenerateReport(ctx context.Context, auditID, userID, format string) (*domain.ReportFile, error) {
+	renderer, ok := s.renderers[format]
+	if !ok {
+		return nil, fmt.Errorf("%w: format must be pdf, docx or html", domain.ErrInvalidInput)
+	}
+
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionReportRead); err != nil {
+		return nil, err
+	}
+
+	report, err := s.buildReport(ctx, auditID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := renderer.Render(&buf, report); err != nil {
+		return nil, err
+	}
+
+	return &domain.ReportFile{
+		FileName:    fmt.Sprintf("informe-%s.%s", slug(report.Audit.Title), format),
+		ContentType: renderer.ContentType(),
+		Content:     buf.Bytes(),
+	}, nil
+}
+
+func (s *ReportService) buildReport(ctx context.Context, auditID string) (*domain.AuditReport, error) {
+	audit, err := s.auditRepo.GetAuditByID(ctx, auditID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+
+	assignments, err := s.auditRepo.ListAuditAssignments(ctx, auditID)
+	if err != nil {
+		return nil, err
+	}
+	team := make([]domain.AuditReportMember, 0, len(assignments))
+	for _, a := range assignments {
+		if !a.IsActive {
+			continue
+		}
+		email := a.UserID
+		if user, err := s.authRepo.FindUserByID(ctx, a.UserID); err == nil {
+			email = user.Email
+		}
+		team = append(team, domain.AuditReportMember{
+			Email:            email,
+			RoleInAudit:      a.RoleInAudit,
+			AcceptanceStatus: a.AcceptanceStatus,
+		})
+	}
+
+	checklist, err := s.auditRepo.GetChecklistByAuditID(ctx, auditID)
+	if err != nil {
+		return nil, err
+	}
+	summary := map[domain.ChecklistAnswer]int{}
+	for _, item := range checklist {
+		summary[item.Answer]++
+	}
+
+	findings, err := s.findingRepo.ListFindingsByAudit(ctx, auditID)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := s.GetTemplate(ctx, audit.OrgOwnerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuditReport{
+		Audit:       *audit,
+		Template:    *tpl,
+		Team:        team,
+		Checklist:   checklist,
+		Findings:    findings,
+		Summary:     summary,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+func (s *ReportService) SetConclusions(ctx context.Context, auditID, userID, conclusions string) error {
+	if _, err := authorizeAudit(ctx, s.auditRepo, auditID, userID, domain.ActionReportConclusions); err != nil {
+		return err
+	}
+	return s.auditRepo.UpdateAuditConclusions(ctx, auditID, conclusions)
+}
+
+// GetTemplate devuelve la plantilla de la organización o una predeterminada si no la personalizó
+func (s *ReportService) GetTemplate(ctx context.Context, orgID string) (*domain.ReportTemplate, error) {
+	tpl, err := s.templateRepo.GetReportTemplate(ctx, orgID)
+	if err != nil {
+		return &domain.ReportTemplate{
+			OrganizationID: orgID,
+			Title:          "Informe de Auditoría",
+			Intro:          "Informe de resultados de la auditoría del sistema de gestión.",
+		}, nil
+	}
+	return tpl, nil
+}
+
+// SaveTemplate guarda la plantilla de la organización. El HTML personalizado puede traer
+// scripts: el handler lo sirve siempre como descarga y con CSP sandbox.
+func (s *ReportService) SaveTemplate(ctx context.Context, orgID, userID string, tpl *domain.ReportTemplate) (*domain.ReportTemplate, error) {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionReportTemplate); err != nil {
+		return nil, err
+	}
+	tpl.OrganizationID = orgID
+	if tpl.Title == "" {
+		return nil, fmt.Errorf("%w: title is required", domain.ErrInvalidInput)
+	}
+	// Validar el template HTML antes de guardarlo para no romper los informes
+	if tpl.HTMLTemplate != "" {
+		if err := s.renderers["html"].Render(&bytes.Buffer{}, &domain.AuditReport{Template: *tpl}); err != nil {
+			return nil, fmt.Errorf("%w: html_template: %v", domain.ErrInvalidInput, err)
+		}
+	}
+	if err := s.templateRepo.SaveReportTemplate(ctx, tpl); err != nil {
+		return nil, err
+	}
+	return tpl, nil
+}
+
+// slug genera un nombre de archivo seguro a partir del título
+func slug(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case b.Len() > 0 && !strings.HasSuffix(b.String(), "-"):
+			b.WriteByte('-')
+		}
+	}
+	if b.Len() == 0 {
+		return "auditoria"
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/RiosHectorM/iso-stack/internal/adapters/report"
+	"github.com/RiosHectorM/iso-stack/internal/adapters/repository"
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+)
+
+type reportFixture struct {
+	repo    *repository.MemoryRepository
+	reports *ReportService
+	org     *domain.Organization
+	ownerID string
+	leadID  string
+}
+
+func newReportFixture(t *testing.T) *reportFixture {
+	t.Helper()
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+	owner := &domain.User{Email: "owner@example.com", Password: "x"}
+	org := &domain.Organization{Name: "Consultora"}
+	if err := repo.CreateUserWithOrg(ctx, owner, org, &domain.UserOrganization{RoleDefault: domain.RoleConsultora, JoinedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	lead := &domain.User{Email: "lead@example.com", Password: "x"}
+	if err := repo.CreateUserAndAddToOrg(ctx, lead, &domain.UserOrganization{OrganizationID: org.ID, RoleDefault: domain.RoleAuditorLider, Status: domain.MemberActivo, JoinedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	return &reportFixture{
+		repo:    repo,
+		reports: NewReportService(repo, nil, repo, repo, repo, report.Renderers()),
+		org:     org,
+		ownerID: owner.ID,
+		leadID:  lead.ID,
+	}
+}
+
+func TestSaveTemplate(t *testing.T) {
+	f := newReportFixture(t)
+	ctx := context.Background()
+
+	saved, err := f.reports.SaveTemplate(ctx, f.org.ID, f.ownerID, &domain.ReportTemplate{
+		OrganizationID: "otra-org", // Se ignora: la plantilla es siempre de la organización activa
+		Title:          "Informe de auditoría",
+		Header:         "Consultora S.A.",
+		HTMLTemplate:   `<h1>{{.Template.Title}}</h1><p>{{.Audit.Title}}</p>`,
+	})
+	if err != nil {
+		t.Fatalf("SaveTemplate: %v", err)
+	}
+	if saved.OrganizationID != f.org.ID {
+		t.Fatalf("template saved for %q, want %q", saved.OrganizationID, f.org.ID)
+	}
+	got, err := f.reports.GetTemplate(ctx, f.org.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Informe de auditoría" || got.Header != "Consultora S.A." || got.HTMLTemplate == "" {
+		t.Fatalf("GetTemplate returned %+v", got)
+	}
+
+	// Un template que no compila no se guarda
+	_, err = f.reports.SaveTemplate(ctx, f.org.ID, f.ownerID, &domain.ReportTemplate{Title: "Roto", HTMLTemplate: "{{.Audit.Title"})
+	if !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("invalid html_template: got %v, want ErrInvalidInput", err)
+	}
+	if got, _ := f.reports.GetTemplate(ctx, f.org.ID); got.Title != "Informe de auditoría" {
+		t.Fatalf("an invalid template replaced the saved one: %+v", got)
+	}
+}
+
+func TestSaveTemplateRequiresPermission(t *testing.T) {
+	f := newReportFixture(t)
+	ctx := context.Background()
+
+	for _, userID := range []string{f.leadID, "someone-else"} {
+		_, err := f.reports.SaveTemplate(ctx, f.org.ID, userID, &domain.ReportTemplate{Title: "Informe"})
+		if !errors.Is(err, domain.ErrForbidden) {
+			t.Fatalf("user %s: got %v, want ErrForbidden", userID, err)
+		}
+	}
+	if _, err := f.repo.GetReportTemplate(ctx, f.org.ID); err == nil {
+		t.Fatal("a forbidden SaveTemplate stored the template")
+	}
+}
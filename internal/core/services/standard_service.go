@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/RiosHectorM/iso-stack/internal/core/domain"
+	"github.com/RiosHectorM/iso-stack/internal/core/ports"
+)
+
+// StandardService administra el catálogo de normas y arma checklists a partir de él
+type StandardService struct {
+	repo         ports.StandardRepository
+	orgRepo      ports.OrganizationRepository
+	auditService ports.AuditService
+}
+
+func NewStandardService(repo ports.StandardRepository, orgRepo ports.OrganizationRepository, auditService ports.AuditService) *StandardService {
+	return &StandardService{
+		repo:         repo,
+		orgRepo:      orgRepo,
+		auditService: auditService,
+	}
+}
+
+func (s *StandardService) ListStandards(ctx context.Context, orgID string) ([]domain.Standard, error) {
+	return s.repo.ListStandards(ctx, orgID)
+}
+
+func (s *StandardService) GetStandard(ctx context.Context, orgID, standardID string) (*domain.StandardDetail, error) {
+	standard, err := s.repo.FindStandard(ctx, orgID, standardID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	clauses, err := s.repo.ListClauses(ctx, standard.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.StandardDetail{Standard: *standard, Clauses: clauseTree(clauses)}, nil
+}
+
+// ImportStandard valida la jerarquía y guarda la norma con sus cláusulas.
+// Un ParentNumber vacío se deduce del número ("7.1.5" -> "7.1") si esa cláusula existe.
+func (s *StandardService) ImportStandard(ctx context.Context, orgID, userID string, standard *domain.Standard, clauses []domain.Clause) (*domain.StandardDetail, error) {
+	// orgID vacío = catálogo global: solo se carga por fuera de la API, con `isoctl standards import`
+	if orgID != "" {
+		if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionStandardManage); err != nil {
+			return nil, err
+		}
+	}
+
+	standard.Code = strings.TrimSpace(standard.Code)
+	standard.Version = strings.TrimSpace(standard.Version)
+	if standard.Code == "" || standard.Version == "" {
+		return nil, fmt.Errorf("%w: code and version are required", domain.ErrInvalidInput)
+	}
+	if len(clauses) == 0 {
+		return nil, fmt.Errorf("%w: a standard needs at least one clause", domain.ErrInvalidInput)
+	}
+
+	numbers := make(map[string]bool, len(clauses))
+	for i := range clauses {
+		clauses[i].Number = strings.TrimSpace(clauses[i].Number)
+		if clauses[i].Number == "" {
+			return nil, fmt.Errorf("%w: clause %d has no number", domain.ErrInvalidInput, i+1)
+		}
+		if numbers[clauses[i].Number] {
+			return nil, fmt.Errorf("%w: clause %s is duplicated", domain.ErrInvalidInput, clauses[i].Number)
+		}
+		numbers[clauses[i].Number] = true
+	}
+	// Un padre explícito tiene que aparecer antes que sus hijas: evita ciclos
+	seen := make(map[string]bool, len(clauses))
+	for i := range clauses {
+		c := &clauses[i]
+		if c.ParentNumber == "" {
+			if dot := strings.LastIndex(c.Number, "."); dot > 0 && numbers[c.Number[:dot]] {
+				c.ParentNumber = c.Number[:dot]
+			}
+		} else if !seen[c.ParentNumber] {
+			return nil, fmt.Errorf("%w: clause %s must come after its parent %s", domain.ErrInvalidInput, c.Number, c.ParentNumber)
+		}
+		seen[c.Number] = true
+		c.ID = ""
+		c.Position = i
+	}
+
+	imported := &domain.Standard{
+		Code:           standard.Code,
+		Version:        standard.Version,
+		OrganizationID: orgID,
+		Title:          standard.Title,
+		CreatedBy:      userID,
+	}
+	if existing, err := s.repo.ListStandards(ctx, orgID); err == nil {
+		for _, e := range existing {
+			if e.Code == imported.Code && e.Version == imported.Version && e.OrganizationID == orgID {
+				return nil, fmt.Errorf("%w: %s already exists", domain.ErrConflict, imported.Label())
+			}
+		}
+	}
+	if err := s.repo.CreateStandard(ctx, imported, clauses); err != nil {
+		return nil, err
+	}
+	return &domain.StandardDetail{Standard: *imported, Clauses: clauseTree(clauses)}, nil
+}
+
+// DeleteStandard borra una norma privada; las auditorías conservan sus ítems de checklist
+func (s *StandardService) DeleteStandard(ctx context.Context, orgID, standardID, userID string) error {
+	if err := authorizeOrg(ctx, s.orgRepo, userID, orgID, domain.ActionStandardManage); err != nil {
+		return err
+	}
+	return s.repo.DeleteStandard(ctx, orgID, standardID)
+}
+
+// BuildChecklist convierte cláusulas del catálogo en ítems del checklist. Las cláusulas
+// sin requisito (títulos de capítulo) se omiten. AddChecklistItems aplica los permisos.
+func (s *StandardService) BuildChecklist(ctx context.Context, auditID, userID, orgID, standardID string, numbers []string) ([]domain.ChecklistItem, error) {
+	standard, err := s.repo.FindStandard(ctx, orgID, standardID)
+	if err != nil {
+		return nil, domain.ErrNotFound
+	}
+	clauses, err := s.repo.ListClauses(ctx, standard.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(numbers))
+	for _, n := range numbers {
+		wanted[n] = true
+	}
+
+	var items []domain.ChecklistItem
+	for _, c := range clauses {
+		if len(wanted) > 0 && !wanted[c.Number] {
+			continue
+		}
+		delete(wanted, c.Number)
+		if c.Requirement == "" {
+			continue
+		}
+		items = append(items, domain.ChecklistItem{
+			Standard:         standard.Label(),
+			ClauseNumber:     c.Number,
+			Requirement:      c.Requirement,
+			ExpectedEvidence: c.ExpectedEvidence,
+			ClauseID:         c.ID,
+		})
+	}
+	for n := range wanted {
+		return nil, fmt.Errorf("%w: clause %s does not exist in %s", domain.ErrInvalidInput, n, standard.Label())
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: the selected clauses have no requirements", domain.ErrInvalidInput)
+	}
+	return s.auditService.AddChecklistItems(ctx, auditID, userID, items)
+}
+
+// clauseTree arma la jerarquía respetando el orden de Position
+func clauseTree(clauses []domain.Clause) []domain.ClauseNode {
+	children := make(map[string][]domain.Clause)
+	var roots []domain.Clause
+	for _, c := range clauses {
+		if c.ParentNumber == "" {
+			roots = append(roots, c)
+		} else {
+			children[c.ParentNumber] = append(children[c.ParentNumber], c)
+		}
+	}
+
+	var build func([]domain.Clause) []domain.ClauseNode
+	build = func(level []domain.Clause) []domain.ClauseNode {
+		nodes := make([]domain.ClauseNode, 0, len(level))
+		for _, c := range level {
+			nodes = append(nodes, domain.ClauseNode{Clause: c, Children: build(children[c.Number])})
+		}
+		return nodes
+	}
+	return build(roots)
+}